@@ -0,0 +1,69 @@
+package timex
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// ActiveWindow 描述功能开关的时间条件: 在放量时间范围内按比例逐步开放, 并且可以为不同受众设置各自时区下的定时生效窗口.
+// 判断是否生效只依赖传入的 Clock 和参数, 结果是确定性的, 便于测试.
+type ActiveWindow struct {
+	clock     Clock
+	ramp      *TimeRange
+	schedules map[string]*Matcher
+}
+
+// NewActiveWindow 创建ActiveWindow. ramp 为放量时间范围, 开放比例在其中从 0 线性增长到 1, 为 nil 时表示不做渐进放量.
+func NewActiveWindow(clock Clock, ramp *TimeRange) *ActiveWindow {
+	return &ActiveWindow{
+		clock:     clock,
+		ramp:      ramp,
+		schedules: map[string]*Matcher{},
+	}
+}
+
+// SetAudienceSchedule 为受众设置定时生效窗口, 如 And(OnWeekday(time.Monday), BetweenTimesOfDay(...)).In(loc).
+// 未设置窗口的受众在任何时间都满足定时条件.
+func (w *ActiveWindow) SetAudienceSchedule(audience string, schedule *Matcher) {
+	w.schedules[audience] = schedule
+}
+
+// RampFraction 返回 t 时的开放比例: 放量开始前为 0, 结束后为 1, 期间线性增长. 没有设置放量范围时总是返回 1.
+func (w *ActiveWindow) RampFraction(t time.Time) float64 {
+	if w.ramp == nil || !w.ramp.end.After(w.ramp.start) {
+		return 1
+	}
+	switch {
+	case !t.After(w.ramp.start):
+		return 0
+	case !t.Before(w.ramp.end):
+		return 1
+	default:
+		return float64(t.Sub(w.ramp.start)) / float64(w.ramp.end.Sub(w.ramp.start))
+	}
+}
+
+// IsActive 判断当前时间 (由 Clock 给出) 下, 功能对受众 audience 中以 key 标识的对象 (如用户 ID) 是否生效
+func (w *ActiveWindow) IsActive(audience, key string) bool {
+	return w.ActiveAt(w.clock.Now(), audience, key)
+}
+
+// ActiveAt 判断 t 时功能对受众 audience 中以 key 标识的对象是否生效.
+// 对象按 key 的哈希值稳定地分配到 [0, 1) 中的位置, 位置小于开放比例时生效, 因此放量过程中已经生效的对象不会再失效.
+func (w *ActiveWindow) ActiveAt(t time.Time, audience, key string) bool {
+	if m, ok := w.schedules[audience]; ok && !m.Match(t) {
+		return false
+	}
+	fraction := w.RampFraction(t)
+	if fraction >= 1 {
+		return true
+	}
+	return hashFraction(key) < fraction
+}
+
+// hashFraction 将字符串稳定地映射到 [0, 1) 中
+func hashFraction(s string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
@@ -0,0 +1,62 @@
+package timex
+
+import "time"
+
+const (
+	activityBin        = 15 * time.Minute // 活动时间直方图的粒度, 足以区分 +05:30, +05:45 这类偏移
+	activityBins       = int(24 * time.Hour / activityBin)
+	activityQuietBins  = int(6 * time.Hour / activityBin)
+	activityQuietStart = time.Hour // 假定用户在当地 01:00 到 07:00 之间最不活跃
+)
+
+// InferUTCOffset 根据用户的活动时间推测其所在时区相对 UTC 的偏移分钟数, 可用于选择消息的发送时间.
+// 它将活动时间按 UTC 时刻统计为 15 分钟粒度的直方图, 找出活动最少的连续 6 小时, 并假定这段时间对应当地的 01:00 到 07:00.
+// 结果在 -720 (不含) 到 840 之间. confidence 在 0 到 1 之间, 安静时段越明显, 样本越多则越高; 没有活动时间时均返回 0.
+// 这只是基于作息习惯的推测, 对夜间活跃的用户或样本很少时并不可靠.
+func InferUTCOffset(activity []time.Time) (offsetMinutes int, confidence float64) {
+	if len(activity) == 0 {
+		return 0, 0
+	}
+	var bins [activityBins]int
+	for _, t := range activity {
+		t = t.UTC()
+		bins[(t.Hour()*60+t.Minute())/int(activityBin/time.Minute)]++
+	}
+
+	sums := make([]int, activityBins)
+	minSum := len(activity)
+	for s := range sums {
+		for k := 0; k < activityQuietBins; k++ {
+			sums[s] += bins[(s+k)%activityBins]
+		}
+		minSum = min(minSum, sums[s])
+	}
+	// 多个窗口同样安静时, 取最长的一段连续安静窗口的中间位置
+	bestStart, bestLen := 0, 0
+	for s := 0; s < activityBins; s++ {
+		if sums[s] != minSum || (s > 0 && sums[s-1] == minSum) {
+			continue
+		}
+		n := 0
+		for n < activityBins && sums[(s+n)%activityBins] == minSum {
+			n++
+		}
+		if n > bestLen {
+			bestStart, bestLen = s, n
+		}
+	}
+	quiet := (bestStart + bestLen/2) % activityBins
+
+	offset := int((activityQuietStart - time.Duration(quiet)*activityBin) / time.Minute)
+	for offset <= -720 {
+		offset += 24 * 60
+	}
+	for offset > 840 {
+		offset -= 24 * 60
+	}
+
+	n := float64(len(activity))
+	expected := n * float64(activityQuietBins) / float64(activityBins)
+	confidence = max(0, 1-float64(minSum)/expected) * n / (n + 20)
+	return offset, confidence
+}
@@ -0,0 +1,24 @@
+package timex
+
+import "time"
+
+// Age 返回在 loc 中 at 时的周岁年龄, 以及距离上一个生日 (或上一个月的生日对应日) 经过的月数和天数.
+// 年龄只按照日期计算, 生日当天即增加一岁; 生日所在的月份没有对应日期时取该月最后一天, 如 02-29 出生的人在非闰年的 02-28 增加一岁.
+// at 早于 birth 时返回全 0.
+func Age(birth, at time.Time, loc *time.Location) (years, months, days int) {
+	b, a := DateOfByTz(birth, loc), DateOfByTz(at, loc)
+	if a.Before(b) {
+		return 0, 0, 0
+	}
+	n := (a.Year-b.Year)*12 + int(a.Month) - int(b.Month)
+	if b.AddMonths(n).After(a) {
+		n--
+	}
+	return n / 12, n % 12, a.DaysSince(b.AddMonths(n))
+}
+
+// AgeYears 返回在 loc 中 at 时的周岁年龄, 规则与 Age 相同
+func AgeYears(birth, at time.Time, loc *time.Location) int {
+	years, _, _ := Age(birth, at, loc)
+	return years
+}
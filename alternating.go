@@ -0,0 +1,40 @@
+package timex
+
+import "time"
+
+// AlternatingWindows 从时间范围的开始时间起将其切分为时长为 width 的连续窗口, 并依次轮流分配给 phases 个阶段, 适合按时间片轮换实验组的 A/B 实验.
+// 第 i 个窗口 (从 0 开始) 属于第 i % phases 个阶段, 最后一个窗口可能不足 width. 窗口为左闭右开区间, 与时间范围边界重合的一端沿用时间范围的开闭性.
+// width 或 phases 不大于 0 时返回 nil.
+func AlternatingWindows(tr *TimeRange, width time.Duration, phases int) [][]*TimeRange {
+	if width <= 0 {
+		return nil
+	}
+	return alternate(tr, phases, func(t time.Time) time.Time {
+		return t.Add(width)
+	})
+}
+
+// AlternatingWindowsByUnit 与 AlternatingWindows 相同, 但按照 loc 中的日历周期切分窗口, 如 UnitDay 表示每个自然日为一个窗口, 不受夏令时切换影响.
+// 时间范围的开始时间不在周期边界上时, 第一个窗口是从开始时间到下一个周期边界的不完整周期, 它同样属于第 0 个阶段.
+func AlternatingWindowsByUnit(tr *TimeRange, unit Unit, phases int, loc *time.Location) [][]*TimeRange {
+	return alternate(tr, phases, func(t time.Time) time.Time {
+		return addUnits(TruncateTo(t, unit, loc), unit, 1, loc)
+	})
+}
+
+// alternate 从时间范围的开始时间起依次以 next 计算下一个窗口的开始时间, 并将窗口轮流分配给各个阶段
+func alternate(tr *TimeRange, phases int, next func(time.Time) time.Time) [][]*TimeRange {
+	if phases <= 0 {
+		return nil
+	}
+	result := make([][]*TimeRange, phases)
+	for i, s := 0, tr.start; s.Before(tr.end); i++ {
+		e := next(s)
+		if !e.Before(tr.end) {
+			e = tr.end
+		}
+		result[i%phases] = append(result[i%phases], segmentToTimeRange(s, e, tr.start, tr.end, tr))
+		s = e
+	}
+	return result
+}
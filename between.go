@@ -0,0 +1,64 @@
+package timex
+
+import "time"
+
+// DaysBetween 返回在 loc 中从 a 到 b 经过的完整天数, b 早于 a 时为负数.
+// 一天指的是墙上时刻相同的相邻两个日期之间的时间, 因此跨越夏令时切换的 23 或 25 小时也算作一天.
+// 只需要比较日期时请使用 Date.DaysSince.
+func DaysBetween(a, b time.Time, loc *time.Location) int {
+	n, _ := calendarBetween(a, b, loc, func(n int) Period { return Period{Days: n} })
+	return n
+}
+
+// MonthsBetween 返回在 loc 中从 a 到 b 经过的完整月数, b 早于 a 时为负数.
+// 月份的推算与 Period.AddTo 一致, 目标月份没有对应日期时取该月最后一天, 如 01-31 到 02-29 算作一个月.
+func MonthsBetween(a, b time.Time, loc *time.Location) int {
+	n, _ := calendarBetween(a, b, loc, func(n int) Period { return Period{Months: n} })
+	return n
+}
+
+// YearsBetween 返回在 loc 中从 a 到 b 经过的完整年数, b 早于 a 时为负数, 02-29 到次年 02-28 算作一年
+func YearsBetween(a, b time.Time, loc *time.Location) int {
+	n, _ := calendarBetween(a, b, loc, func(n int) Period { return Period{Years: n} })
+	return n
+}
+
+// FractionalDaysBetween 与 DaysBetween 相同, 但不足一天的部分按其占所在那一天实际时长的比例计入
+func FractionalDaysBetween(a, b time.Time, loc *time.Location) float64 {
+	n, f := calendarBetween(a, b, loc, func(n int) Period { return Period{Days: n} })
+	return float64(n) + f
+}
+
+// FractionalMonthsBetween 与 MonthsBetween 相同, 但不足一个月的部分按其占所在那个月实际时长的比例计入
+func FractionalMonthsBetween(a, b time.Time, loc *time.Location) float64 {
+	n, f := calendarBetween(a, b, loc, func(n int) Period { return Period{Months: n} })
+	return float64(n) + f
+}
+
+// FractionalYearsBetween 与 YearsBetween 相同, 但不足一年的部分按其占所在那一年实际时长的比例计入
+func FractionalYearsBetween(a, b time.Time, loc *time.Location) float64 {
+	n, f := calendarBetween(a, b, loc, func(n int) Period { return Period{Years: n} })
+	return float64(n) + f
+}
+
+// calendarBetween 返回在 loc 中从 a 到 b 经过的完整单位个数 n, 以及剩余部分占下一个单位的比例 f, b 早于 a 时两者均为非正数.
+// 第 n 个单位的结束时间为 a 加上 unit(n) (见 Period.AddTo), 总是从 a 直接推算, 不会因月末截断而逐渐漂移.
+func calendarBetween(a, b time.Time, loc *time.Location, unit func(n int) Period) (int, float64) {
+	if b.Before(a) {
+		n, f := calendarBetween(b, a, loc, unit)
+		return -n, -f
+	}
+
+	a = a.In(loc)
+	anchor := func(n int) time.Time { return unit(n).AddTo(a) }
+	// 先按照第一个单位的时长估计, 再逐个调整
+	n := int(b.Sub(a) / anchor(1).Sub(a))
+	for n > 0 && anchor(n).After(b) {
+		n--
+	}
+	for !anchor(n + 1).After(b) {
+		n++
+	}
+	s, e := anchor(n), anchor(n+1)
+	return n, float64(b.Sub(s)) / float64(e.Sub(s))
+}
@@ -0,0 +1,288 @@
+package timex
+
+import (
+	"errors"
+	"math/bits"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrInvalidWorkingHours 表示无效的工作时间
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+	// ErrInvalidWeekend 表示无效的周末定义, 如一周七天都是周末
+	ErrInvalidWeekend = errors.New("invalid weekend")
+)
+
+// BusinessCalendar 表示某个时区中的工作日历, 工作日为周末以外的非节假日, 每个工作日有固定的工作时间.
+// 周末默认为周六和周日, 可以通过 SetWeekend 修改.
+type BusinessCalendar struct {
+	loc       *time.Location
+	workStart TimeOfDay
+	workEnd   TimeOfDay
+	weekend   WeekdaySet
+	holidays  *StaticHolidayCalendar // 通过 AddHolidays 添加的节假日
+	external  HolidayCalendar        // 通过 SetHolidayCalendar 接入的节假日, 可以为 nil
+}
+
+// MustNewBusinessCalendar 创建BusinessCalendar, 如果参数无效则 panic
+func MustNewBusinessCalendar(loc *time.Location, workStart, workEnd TimeOfDay) *BusinessCalendar {
+	c, err := NewBusinessCalendar(loc, workStart, workEnd)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewBusinessCalendar 创建BusinessCalendar, 每个工作日的工作时间为 loc 中的 [workStart, workEnd)
+func NewBusinessCalendar(loc *time.Location, workStart, workEnd TimeOfDay) (*BusinessCalendar, error) {
+	if !workStart.IsValid() || !workEnd.IsValid() || !workStart.Before(workEnd) {
+		return nil, ErrInvalidWorkingHours
+	}
+	return &BusinessCalendar{
+		loc:       loc,
+		workStart: workStart,
+		workEnd:   workEnd,
+		weekend:   DefaultWeekend,
+		holidays:  NewStaticHolidayCalendar(),
+	}, nil
+}
+
+// Location 返回工作日历所在的时区
+func (c *BusinessCalendar) Location() *time.Location {
+	return c.loc
+}
+
+// Weekend 返回周末的定义
+func (c *BusinessCalendar) Weekend() WeekdaySet {
+	return c.weekend
+}
+
+// SetWeekend 修改周末的定义, 如 NewWeekdaySet(time.Friday, time.Saturday). weekend 包含一周全部七天时返回 ErrInvalidWeekend.
+func (c *BusinessCalendar) SetWeekend(weekend WeekdaySet) error {
+	if weekend&allWeekdays == allWeekdays {
+		return ErrInvalidWeekend
+	}
+	c.weekend = weekend
+	return nil
+}
+
+// AddHolidays 添加节假日
+func (c *BusinessCalendar) AddHolidays(dates ...Date) {
+	c.holidays.Add(dates...)
+}
+
+// SetHolidayCalendar 接入外部的节假日数据, 与通过 AddHolidays 添加的节假日同时生效, hc 为 nil 时取消接入
+func (c *BusinessCalendar) SetHolidayCalendar(hc HolidayCalendar) {
+	c.external = hc
+}
+
+// IsBusinessDay 判断日期是否为工作日. 接入的节假日数据实现了 MakeupWorkdayCalendar 时, 调休上班日即使落在周末也是工作日.
+func (c *BusinessCalendar) IsBusinessDay(d Date) bool {
+	if c.isHoliday(d) {
+		return false
+	}
+	return !c.weekend.Contains(d.Weekday()) || c.isMakeupWorkday(d)
+}
+
+// isHoliday 判断日期是否为节假日
+func (c *BusinessCalendar) isHoliday(d Date) bool {
+	return c.holidays.IsHoliday(d) || (c.external != nil && c.external.IsHoliday(d))
+}
+
+// isMakeupWorkday 判断日期是否为接入的节假日数据中的调休上班日
+func (c *BusinessCalendar) isMakeupWorkday(d Date) bool {
+	mc, ok := c.external.(MakeupWorkdayCalendar)
+	return ok && mc.IsMakeupWorkday(d)
+}
+
+// workingWindow 返回指定日期的工作时间段, 非工作日返回 false
+func (c *BusinessCalendar) workingWindow(d Date) (start, end time.Time, ok bool) {
+	if !c.IsBusinessDay(d) {
+		return start, end, false
+	}
+	return c.workStart.On(d, c.loc), c.workEnd.On(d, c.loc), true
+}
+
+// nextWorkingInstant 返回不早于 t 的第一个工作时间点
+func (c *BusinessCalendar) nextWorkingInstant(t time.Time) time.Time {
+	for d := DateOfByTz(t, c.loc); ; d = d.AddDays(1) {
+		start, end, ok := c.workingWindow(d)
+		if !ok || !t.Before(end) {
+			continue
+		}
+		if t.Before(start) {
+			return start
+		}
+		return t
+	}
+}
+
+// addWorkingDuration 返回从 t 开始经过 d 的工作时长之后的时间点, 非工作时间不计入
+func (c *BusinessCalendar) addWorkingDuration(t time.Time, d time.Duration) time.Time {
+	t = c.nextWorkingInstant(t)
+	for {
+		_, end, _ := c.workingWindow(DateOfByTz(t, c.loc))
+		remain := end.Sub(t)
+		if d <= remain {
+			return t.Add(d)
+		}
+		d -= remain
+		t = c.nextWorkingInstant(end)
+	}
+}
+
+// AddBusinessDays 返回 t 之后第 n 个工作日中与 t 墙上时刻相同的时间, n 为负数时向前计算, n 为 0 时返回 t 本身 (即使 t 不是工作日).
+// 日期按照 cal 的时区计算, 结果也位于该时区. cal 为 nil 时只跳过周六和周日, 并使用 t 自身的时区.
+func AddBusinessDays(t time.Time, n int, cal *BusinessCalendar) time.Time {
+	cal = calendarOrDefault(cal, t.Location())
+	t = t.In(cal.loc)
+	return TimeOfDayOf(t).On(cal.addBusinessDays(DateOf(t), n), cal.loc)
+}
+
+// BusinessDaysBetween 返回从 a 所在日期 (不包含) 到 b 所在日期 (包含) 之间的工作日数, b 早于 a 时为负数,
+// 因此对于工作日 b, AddBusinessDays(a, BusinessDaysBetween(a, b, cal), cal) 与 b 在同一天.
+// 日期按照 cal 的时区计算, cal 为 nil 时只跳过周六和周日, 并使用 a 自身的时区.
+func BusinessDaysBetween(a, b time.Time, cal *BusinessCalendar) int {
+	cal = calendarOrDefault(cal, a.Location())
+	from, to := DateOfByTz(a, cal.loc), DateOfByTz(b, cal.loc)
+	if to.Before(from) {
+		return -cal.businessDaysBetween(to, from)
+	}
+	return cal.businessDaysBetween(from, to)
+}
+
+// calendarOrDefault 在 cal 为 nil 时返回 loc 中只把周六和周日视为非工作日的日历
+func calendarOrDefault(cal *BusinessCalendar, loc *time.Location) *BusinessCalendar {
+	if cal != nil {
+		return cal
+	}
+	return &BusinessCalendar{loc: loc, weekend: DefaultWeekend, holidays: NewStaticHolidayCalendar()}
+}
+
+// businessDaysBetween 返回 (from, to] 之间的工作日数, from 不能晚于 to
+func (c *BusinessCalendar) businessDaysBetween(from, to Date) int {
+	days := to.DaysSince(from)
+	weeks := days / 7
+	n := weeks * (7 - bits.OnesCount8(uint8(c.weekend)))
+	for d := from.AddDays(weeks*7 + 1); !d.After(to); d = d.AddDays(1) {
+		if !c.weekend.Contains(d.Weekday()) {
+			n++
+		}
+	}
+	if from == to {
+		return n
+	}
+	dr := &DateRange{start: from.AddDays(1), end: to}
+	for _, h := range c.holidaysIn(dr) {
+		if !c.weekend.Contains(h.Weekday()) {
+			n--
+		}
+	}
+	if mc, ok := c.external.(MakeupWorkdayCalendar); ok {
+		for _, w := range mc.MakeupWorkdaysIn(dr) {
+			if c.weekend.Contains(w.Weekday()) && !c.isHoliday(w) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// holidaysIn 返回日期范围内的所有节假日, 按日期升序排列且互不重复
+func (c *BusinessCalendar) holidaysIn(dr *DateRange) []Date {
+	dates := c.holidays.HolidaysIn(dr)
+	if c.external != nil {
+		dates = append(dates, c.external.HolidaysIn(dr)...)
+		slices.SortFunc(dates, Date.Compare)
+		dates = slices.Compact(dates)
+	}
+	return dates
+}
+
+// NextBusinessDay 返回不早于 d 的第一个工作日, 即按照 RollFollowing 调整, d 本身是工作日时返回 d
+func (c *BusinessCalendar) NextBusinessDay(d Date) Date {
+	return c.rollForward(d)
+}
+
+// PreviousBusinessDay 返回不晚于 d 的最后一个工作日, 即按照 RollPreceding 调整, d 本身是工作日时返回 d
+func (c *BusinessCalendar) PreviousBusinessDay(d Date) Date {
+	return c.rollBackward(d)
+}
+
+// rollForward 返回不早于 d 的第一个工作日
+func (c *BusinessCalendar) rollForward(d Date) Date {
+	for !c.IsBusinessDay(d) {
+		d = d.AddDays(1)
+	}
+	return d
+}
+
+// rollBackward 返回不晚于 d 的最后一个工作日
+func (c *BusinessCalendar) rollBackward(d Date) Date {
+	for !c.IsBusinessDay(d) {
+		d = d.AddDays(-1)
+	}
+	return d
+}
+
+// RollConvention 表示日期不是工作日时调整到工作日的惯例
+type RollConvention int
+
+const (
+	// RollNone 不做调整
+	RollNone RollConvention = iota
+	// RollFollowing 顺延至之后最近的工作日
+	RollFollowing
+	// RollModifiedFollowing 顺延至之后最近的工作日, 若因此跨入下个月, 则改为提前至之前最近的工作日
+	RollModifiedFollowing
+	// RollPreceding 提前至之前最近的工作日
+	RollPreceding
+	// RollModifiedPreceding 提前至之前最近的工作日, 若因此跨入上个月, 则改为顺延至之后最近的工作日
+	RollModifiedPreceding
+	// RollNearest 调整至前后距离最近的工作日, 距离相同时提前, 如周六提前至周五, 周日顺延至周一
+	RollNearest
+)
+
+// Roll 按照惯例 conv 将日期调整为工作日, 日期本身是工作日时不做调整
+func (c *BusinessCalendar) Roll(d Date, conv RollConvention) Date {
+	switch conv {
+	case RollFollowing:
+		return c.rollForward(d)
+	case RollModifiedFollowing:
+		if r := c.rollForward(d); r.Month == d.Month {
+			return r
+		}
+		return c.rollBackward(d)
+	case RollPreceding:
+		return c.rollBackward(d)
+	case RollModifiedPreceding:
+		if r := c.rollBackward(d); r.Month == d.Month {
+			return r
+		}
+		return c.rollForward(d)
+	case RollNearest:
+		prev, next := c.rollBackward(d), c.rollForward(d)
+		if next.DaysSince(d) < d.DaysSince(prev) {
+			return next
+		}
+		return prev
+	default:
+		return d
+	}
+}
+
+// addBusinessDays 返回 d 之后第 n 个工作日, n 为负数时向前计算, n 为 0 时返回 d 本身
+func (c *BusinessCalendar) addBusinessDays(d Date, n int) Date {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		d = d.AddDays(step)
+		for !c.IsBusinessDay(d) {
+			d = d.AddDays(step)
+		}
+	}
+	return d
+}
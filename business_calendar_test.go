@@ -0,0 +1,91 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := MustNewBusinessCalendar(time.UTC, NewTimeOfDay(9, 0, 0), NewTimeOfDay(17, 0, 0))
+	cal.AddHolidays(Date{2024, 5, 1})
+	fri := time.Date(2024, 4, 26, 15, 30, 0, 0, time.UTC)
+	cases := []struct {
+		n    int
+		cal  *BusinessCalendar
+		want Date
+	}{
+		{0, cal, Date{2024, 4, 26}},
+		{1, cal, Date{2024, 4, 29}},
+		{3, cal, Date{2024, 5, 2}},
+		{3, nil, Date{2024, 5, 1}},
+		{-1, cal, Date{2024, 4, 25}},
+		{-5, cal, Date{2024, 4, 19}},
+		{10, cal, Date{2024, 5, 13}},
+	}
+	for _, c := range cases {
+		got := AddBusinessDays(fri, c.n, c.cal)
+		if want := TimeOfDayOf(fri).On(c.want, time.UTC); !got.Equal(want) {
+			t.Errorf("AddBusinessDays(%d, cal=%v) = %v, want %v", c.n, c.cal != nil, got, want)
+		}
+	}
+
+	sat := time.Date(2024, 4, 27, 10, 0, 0, 0, time.UTC)
+	if got := AddBusinessDays(sat, 0, cal); !got.Equal(sat) {
+		t.Errorf("AddBusinessDays(sat, 0) = %v, want the input unchanged", got)
+	}
+	if got, want := AddBusinessDays(sat, 1, cal), time.Date(2024, 4, 29, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("AddBusinessDays(sat, 1) = %v, want %v", got, want)
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := MustNewBusinessCalendar(time.UTC, NewTimeOfDay(9, 0, 0), NewTimeOfDay(17, 0, 0))
+	cal.AddHolidays(Date{2024, 5, 1}, Date{2024, 5, 4}) // 05-04 是周六, 不应重复扣除
+	day := func(d int) time.Time { return time.Date(2024, 4, d, 12, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		a, b time.Time
+		want int
+	}{
+		{day(26), day(26), 0},
+		{day(26), day(29), 1},
+		{day(26), day(27), 0},
+		{day(22), day(26), 4},
+		{day(26), day(22), -4},
+		{day(26), time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC), 9},
+		{day(1), time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), 64},
+	}
+	for _, c := range cases {
+		if got := BusinessDaysBetween(c.a, c.b, cal); got != c.want {
+			t.Errorf("BusinessDaysBetween(%v, %v) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+
+	// 对于工作日 b, AddBusinessDays(a, BusinessDaysBetween(a, b)) 与 b 在同一天
+	for d := (Date{2024, 4, 1}); d.Before(Date{2024, 6, 1}); d = d.AddDays(1) {
+		if !cal.IsBusinessDay(d) {
+			continue
+		}
+		b := d.In(time.UTC)
+		n := BusinessDaysBetween(day(10), b, cal)
+		if got := DateOf(AddBusinessDays(day(10), n, cal)); got != d {
+			t.Errorf("round trip to %v: n = %d, AddBusinessDays gives %v", d, n, got)
+		}
+	}
+}
+
+func TestBusinessDaysCustomWeekend(t *testing.T) {
+	cal := MustNewBusinessCalendar(time.UTC, NewTimeOfDay(8, 0, 0), NewTimeOfDay(16, 0, 0))
+	if err := cal.SetWeekend(NewWeekdaySet(time.Friday, time.Saturday)); err != nil {
+		t.Fatal(err)
+	}
+	thu := time.Date(2024, 4, 25, 9, 0, 0, 0, time.UTC)
+	if got, want := AddBusinessDays(thu, 1, cal), time.Date(2024, 4, 28, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("AddBusinessDays = %v, want %v", got, want)
+	}
+	if got := BusinessDaysBetween(thu, thu.AddDate(0, 0, 7), cal); got != 5 {
+		t.Errorf("BusinessDaysBetween over a week = %d, want 5", got)
+	}
+	if err := cal.SetWeekend(NewWeekdaySet(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)); err != ErrInvalidWeekend {
+		t.Errorf("SetWeekend(all days) = %v, want ErrInvalidWeekend", err)
+	}
+}
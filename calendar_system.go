@@ -0,0 +1,54 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidCalendarDate 表示在历法中不存在的日期
+	ErrInvalidCalendarDate = errors.New("invalid calendar date")
+	// ErrCalendarOutOfRange 表示日期超出了历法实现支持的范围
+	ErrCalendarOutOfRange = errors.New("date out of calendar range")
+)
+
+// CalendarFields 表示某个历法中的日期
+type CalendarFields struct {
+	Year  int
+	Month int  // 月份, 从 1 开始
+	Day   int  // 日, 从 1 开始
+	Leap  bool // 是否为闰月, 只对有闰月的历法有意义
+}
+
+// CalendarSystem 表示一种历法, 用于在公历时间和其他历法的日期之间转换, 以便按照不同的历法格式化和解析日期.
+// 实现只需要处理日期部分, 一天的开始时间统一按照 loc 计算.
+type CalendarSystem interface {
+	// Name 返回历法的名称, 如 "gregorian"
+	Name() string
+	// FromTime 返回时间在 loc 中的日期在该历法中的表示
+	FromTime(t time.Time, loc *time.Location) (CalendarFields, error)
+	// ToTime 返回该历法中的日期在 loc 中的开始时间, 日期不存在时返回 ErrInvalidCalendarDate
+	ToTime(f CalendarFields, loc *time.Location) (time.Time, error)
+}
+
+// GregorianCalendar 是公历的 CalendarSystem 实现, 字段与 Date 一致
+var GregorianCalendar CalendarSystem = gregorianCalendar{}
+
+type gregorianCalendar struct{}
+
+func (gregorianCalendar) Name() string {
+	return "gregorian"
+}
+
+func (gregorianCalendar) FromTime(t time.Time, loc *time.Location) (CalendarFields, error) {
+	d := DateOfByTz(t, loc)
+	return CalendarFields{Year: d.Year, Month: int(d.Month), Day: d.Day}, nil
+}
+
+func (gregorianCalendar) ToTime(f CalendarFields, loc *time.Location) (time.Time, error) {
+	d := Date{Year: f.Year, Month: time.Month(f.Month), Day: f.Day}
+	if f.Leap || !d.IsValid() {
+		return time.Time{}, ErrInvalidCalendarDate
+	}
+	return startOfLocalDay(d, loc), nil
+}
@@ -0,0 +1,179 @@
+package timex
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"slices"
+	"time"
+)
+
+// MakeupWorkdayCalendar 是可以提供调休上班日的 HolidayCalendar.
+// 通过 BusinessCalendar.SetHolidayCalendar 接入时, 调休上班日即使落在周末也视为工作日.
+type MakeupWorkdayCalendar interface {
+	HolidayCalendar
+	// IsMakeupWorkday 判断日期是否为调休上班日
+	IsMakeupWorkday(d Date) bool
+	// MakeupWorkdaysIn 返回日期范围内的所有调休上班日, 按日期升序排列
+	MakeupWorkdaysIn(dr *DateRange) []Date
+}
+
+// ChinaHolidayCalendar 是中国大陆法定节假日的 MakeupWorkdayCalendar 实现, 包括放假日期 (含放假期间的周末) 和调休上班日.
+// 内置 2023 年至 2025 年国务院办公厅公布的安排, 之后年份的数据可以通过 Load 或 LoadFile 加载.
+type ChinaHolidayCalendar struct {
+	years    map[int]chinaHolidayYear
+	holidays *StaticHolidayCalendar
+	workdays *StaticHolidayCalendar
+}
+
+// chinaHolidayYear 是一年的放假安排, 也是 Load 读取的 JSON 格式. 元旦假期可能从上一年的 12 月 31 日开始, 这一天也属于当年的安排.
+type chinaHolidayYear struct {
+	Year     int    `json:"year"`
+	Holidays []Date `json:"holidays"`
+	Workdays []Date `json:"workdays"`
+}
+
+// NewChinaHolidayCalendar 创建包含内置数据的ChinaHolidayCalendar
+func NewChinaHolidayCalendar() *ChinaHolidayCalendar {
+	c := &ChinaHolidayCalendar{years: map[int]chinaHolidayYear{}}
+	c.setYears(builtinChinaHolidays())
+	return c
+}
+
+// Load 从 JSON 中加载放假安排, 格式为按年份组织的数组, 日期格式为 "YYYY-MM-DD", 如
+// [{"year":2026,"holidays":["2026-01-01","2026-01-02"],"workdays":["2026-01-04"]}].
+// JSON 中出现的年份会整体替换已有的数据, 因此也可以用来修正内置数据.
+func (c *ChinaHolidayCalendar) Load(r io.Reader) error {
+	var years []chinaHolidayYear
+	if err := json.NewDecoder(r).Decode(&years); err != nil {
+		return err
+	}
+	c.setYears(years)
+	return nil
+}
+
+// LoadFile 从 JSON 文件中加载放假安排, 格式见 Load
+func (c *ChinaHolidayCalendar) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Load(f)
+}
+
+// Years 返回已有放假安排的年份, 没有放假安排的年份中所有日期都不是节假日
+func (c *ChinaHolidayCalendar) Years() []int {
+	var years []int
+	for y := range c.years {
+		years = append(years, y)
+	}
+	slices.Sort(years)
+	return years
+}
+
+// IsHoliday 判断日期是否为放假日
+func (c *ChinaHolidayCalendar) IsHoliday(d Date) bool {
+	return c.holidays.IsHoliday(d)
+}
+
+// HolidaysIn 返回日期范围内的所有放假日, 按日期升序排列
+func (c *ChinaHolidayCalendar) HolidaysIn(dr *DateRange) []Date {
+	return c.holidays.HolidaysIn(dr)
+}
+
+// IsMakeupWorkday 判断日期是否为调休上班日
+func (c *ChinaHolidayCalendar) IsMakeupWorkday(d Date) bool {
+	return c.workdays.IsHoliday(d)
+}
+
+// MakeupWorkdaysIn 返回日期范围内的所有调休上班日, 按日期升序排列
+func (c *ChinaHolidayCalendar) MakeupWorkdaysIn(dr *DateRange) []Date {
+	return c.workdays.HolidaysIn(dr)
+}
+
+// setYears 用 years 替换对应年份的放假安排
+func (c *ChinaHolidayCalendar) setYears(years []chinaHolidayYear) {
+	for _, y := range years {
+		c.years[y.Year] = y
+	}
+	c.holidays, c.workdays = NewStaticHolidayCalendar(), NewStaticHolidayCalendar()
+	for _, y := range c.years {
+		c.holidays.Add(y.Holidays...)
+		c.workdays.Add(y.Workdays...)
+	}
+}
+
+// builtinChinaHolidays 返回内置的放假安排
+func builtinChinaHolidays() []chinaHolidayYear {
+	// days 返回从 year 年 month 月 day 日开始的连续 n 天
+	days := func(year int, month time.Month, day, n int) []Date {
+		dates := make([]Date, n)
+		for i := range dates {
+			dates[i] = NewDate(year, month, day+i)
+		}
+		return dates
+	}
+	join := func(groups ...[]Date) []Date {
+		var dates []Date
+		for _, g := range groups {
+			dates = append(dates, g...)
+		}
+		return dates
+	}
+
+	return []chinaHolidayYear{
+		{
+			Year: 2023,
+			Holidays: join(
+				days(2022, time.December, 31, 3),  // 元旦
+				days(2023, time.January, 21, 7),   // 春节
+				days(2023, time.April, 5, 1),      // 清明节
+				days(2023, time.April, 29, 5),     // 劳动节
+				days(2023, time.June, 22, 3),      // 端午节
+				days(2023, time.September, 29, 8), // 中秋节, 国庆节
+			),
+			Workdays: []Date{
+				NewDate(2023, time.January, 28), NewDate(2023, time.January, 29),
+				NewDate(2023, time.April, 23), NewDate(2023, time.May, 6),
+				NewDate(2023, time.June, 25),
+				NewDate(2023, time.October, 7), NewDate(2023, time.October, 8),
+			},
+		},
+		{
+			Year: 2024,
+			Holidays: join(
+				days(2024, time.January, 1, 1),
+				days(2024, time.February, 10, 8),
+				days(2024, time.April, 4, 3),
+				days(2024, time.May, 1, 5),
+				days(2024, time.June, 10, 1),
+				days(2024, time.September, 15, 3),
+				days(2024, time.October, 1, 7),
+			),
+			Workdays: []Date{
+				NewDate(2024, time.February, 4), NewDate(2024, time.February, 18),
+				NewDate(2024, time.April, 7),
+				NewDate(2024, time.April, 28), NewDate(2024, time.May, 11),
+				NewDate(2024, time.September, 14),
+				NewDate(2024, time.September, 29), NewDate(2024, time.October, 12),
+			},
+		},
+		{
+			Year: 2025,
+			Holidays: join(
+				days(2025, time.January, 1, 1),
+				days(2025, time.January, 28, 8),
+				days(2025, time.April, 4, 3),
+				days(2025, time.May, 1, 5),
+				days(2025, time.May, 31, 3),
+				days(2025, time.October, 1, 8),
+			),
+			Workdays: []Date{
+				NewDate(2025, time.January, 26), NewDate(2025, time.February, 8),
+				NewDate(2025, time.April, 27),
+				NewDate(2025, time.September, 28), NewDate(2025, time.October, 11),
+			},
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package timex
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChinaHolidayCalendarBuiltin(t *testing.T) {
+	cal := NewChinaHolidayCalendar()
+	if got := cal.Years(); !slices.Equal(got, []int{2023, 2024, 2025}) {
+		t.Errorf("Years = %v", got)
+	}
+
+	// 国务院办公厅公布的调休上班日
+	workdays := map[int][]Date{
+		2023: {NewDate(2023, 1, 28), NewDate(2023, 1, 29), NewDate(2023, 4, 23), NewDate(2023, 5, 6), NewDate(2023, 6, 25), NewDate(2023, 10, 7), NewDate(2023, 10, 8)},
+		2024: {NewDate(2024, 2, 4), NewDate(2024, 2, 18), NewDate(2024, 4, 7), NewDate(2024, 4, 28), NewDate(2024, 5, 11), NewDate(2024, 9, 14), NewDate(2024, 9, 29), NewDate(2024, 10, 12)},
+		2025: {NewDate(2025, 1, 26), NewDate(2025, 2, 8), NewDate(2025, 4, 27), NewDate(2025, 9, 28), NewDate(2025, 10, 11)},
+	}
+	// 各年在公历年内的放假天数, 2023 年的元旦假期从 2022-12-31 开始
+	holidayCounts := map[int]int{2022: 1, 2023: 26, 2024: 28, 2025: 28, 2026: 0}
+	for year, count := range holidayCounts {
+		dr := MustNewDateRange(NewDate(year, 1, 1), NewDate(year, 12, 31))
+		if got := len(cal.HolidaysIn(dr)); got != count {
+			t.Errorf("%d has %d holidays, want %d", year, got, count)
+		}
+		if got := cal.MakeupWorkdaysIn(dr); !slices.Equal(got, workdays[year]) {
+			t.Errorf("%d makeup workdays = %v, want %v", year, got, workdays[year])
+		}
+	}
+
+	holidays := []Date{
+		NewDate(2022, 12, 31), NewDate(2023, 1, 2), // 元旦
+		NewDate(2023, 1, 21), NewDate(2023, 1, 27), // 春节
+		NewDate(2023, 9, 29), NewDate(2023, 10, 6), // 中秋节, 国庆节
+		NewDate(2024, 2, 10), NewDate(2024, 2, 17),
+		NewDate(2024, 4, 4), NewDate(2024, 4, 6),
+		NewDate(2024, 6, 10),
+		NewDate(2024, 9, 15), NewDate(2024, 9, 17),
+		NewDate(2025, 1, 28), NewDate(2025, 2, 4),
+		NewDate(2025, 5, 31), NewDate(2025, 6, 2),
+		NewDate(2025, 10, 8),
+	}
+	for _, d := range holidays {
+		if !cal.IsHoliday(d) || cal.IsMakeupWorkday(d) {
+			t.Errorf("%v: IsHoliday = %v, IsMakeupWorkday = %v, want a holiday", d, cal.IsHoliday(d), cal.IsMakeupWorkday(d))
+		}
+	}
+	// 2024 年的除夕不放假, 假期前后的工作日也不是节假日
+	for _, d := range []Date{NewDate(2024, 2, 9), NewDate(2023, 1, 3), NewDate(2023, 10, 9), NewDate(2025, 10, 9)} {
+		if cal.IsHoliday(d) {
+			t.Errorf("%v: IsHoliday = true", d)
+		}
+	}
+}
+
+func TestChinaHolidayCalendarBusinessDays(t *testing.T) {
+	bc := MustNewBusinessCalendar(time.FixedZone("CST", 8*3600), NewTimeOfDay(9, 0, 0), NewTimeOfDay(18, 0, 0))
+	bc.SetHolidayCalendar(NewChinaHolidayCalendar())
+	cases := []struct {
+		d    Date
+		want bool
+	}{
+		// 周六调休上班
+		{NewDate(2023, 1, 28), true},
+		{NewDate(2024, 10, 12), true},
+		// 周一放假
+		{NewDate(2023, 1, 23), false},
+		{NewDate(2024, 9, 16), false},
+		// 普通周末和工作日
+		{NewDate(2024, 3, 9), false},
+		{NewDate(2024, 3, 11), true},
+	}
+	for _, c := range cases {
+		if got := bc.IsBusinessDay(c.d); got != c.want {
+			t.Errorf("IsBusinessDay(%v) = %v, want %v", c.d, got, c.want)
+		}
+	}
+}
+
+func TestChinaHolidayCalendarLoad(t *testing.T) {
+	cal := NewChinaHolidayCalendar()
+	data := `[{"year":2026,"holidays":["2026-01-01","2026-01-02","2026-01-03"],"workdays":["2026-01-04"]},
+		{"year":2025,"holidays":["2025-01-01"],"workdays":[]}]`
+	path := filepath.Join(t.TempDir(), "holidays.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := cal.LoadFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := cal.Years(); !slices.Equal(got, []int{2023, 2024, 2025, 2026}) {
+		t.Errorf("Years = %v", got)
+	}
+	if !cal.IsHoliday(NewDate(2026, 1, 2)) || !cal.IsMakeupWorkday(NewDate(2026, 1, 4)) {
+		t.Error("loaded 2026 data is missing")
+	}
+	// 出现的年份整体替换内置数据
+	if cal.IsHoliday(NewDate(2025, 10, 1)) || cal.IsMakeupWorkday(NewDate(2025, 9, 28)) || !cal.IsHoliday(NewDate(2025, 1, 1)) {
+		t.Error("2025 data was not replaced")
+	}
+	if !cal.IsHoliday(NewDate(2024, 10, 1)) {
+		t.Error("2024 data should be kept")
+	}
+
+	if err := cal.Load(strings.NewReader(`[{"year":2027,"holidays":["2027-13-01"]}]`)); err == nil {
+		t.Error("Load accepted an invalid date")
+	}
+	if err := cal.LoadFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadFile accepted a missing file")
+	}
+}
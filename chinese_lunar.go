@@ -0,0 +1,318 @@
+package timex
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// lunarMinSui, lunarMaxSui 是支持的岁的范围, 第 y 岁指从 y-1 年冬至所在的十一月到 y 年冬至所在的十一月之前的月份
+	lunarMinSui = 1900
+	lunarMaxSui = 2101
+	// julianUnixEpoch 是 unix 零点的儒略日
+	julianUnixEpoch = 2440587.5
+	// synodicMonth 是平均朔望月的天数
+	synodicMonth = 29.530588861
+)
+
+// chinaStandardTime 是农历计算使用的东经 120 度标准时间
+var chinaStandardTime = time.FixedZone("UTC+8", 8*60*60)
+
+// ChineseLunarCalendar 是农历的 CalendarSystem 实现, 支持约 1900 年至 2100 年.
+// 朔日和中气按照 Meeus《天文算法》中的公式以东经 120 度标准时间计算, 以冬至所在的月为十一月, 两个冬至之间有十三个月时第一个不含中气的月为闰月.
+// 太阳位置使用的是精度约为 0.01 度的简化公式, 交节时刻与午夜相差十几分钟以内的个别日期可能与官方历书不一致.
+var ChineseLunarCalendar CalendarSystem = &chineseLunarCalendar{suis: map[int][]lunarMonth{}}
+
+type chineseLunarCalendar struct {
+	mu   sync.Mutex
+	suis map[int][]lunarMonth
+}
+
+// lunarMonth 表示一个农历月
+type lunarMonth struct {
+	year   int // 所属的农历年
+	number int // 月份, 1 到 12
+	leap   bool
+	start  Date // 朔日
+	days   int
+}
+
+func (c *chineseLunarCalendar) Name() string {
+	return "chinese-lunar"
+}
+
+func (c *chineseLunarCalendar) FromTime(t time.Time, loc *time.Location) (CalendarFields, error) {
+	return c.fromDate(DateOfByTz(t, loc))
+}
+
+func (c *chineseLunarCalendar) ToTime(f CalendarFields, loc *time.Location) (time.Time, error) {
+	d, err := c.toDate(f)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return startOfLocalDay(d, loc), nil
+}
+
+// fromDate 返回公历日期对应的农历日期
+func (c *chineseLunarCalendar) fromDate(d Date) (CalendarFields, error) {
+	for _, y := range []int{d.Year, d.Year + 1} {
+		months := c.sui(y)
+		if len(months) == 0 || d.Before(months[0].start) {
+			continue
+		}
+		for _, m := range months {
+			if offset := d.DaysSince(m.start); offset < m.days {
+				return CalendarFields{Year: m.year, Month: m.number, Day: offset + 1, Leap: m.leap}, nil
+			}
+		}
+	}
+	return CalendarFields{}, ErrCalendarOutOfRange
+}
+
+// toDate 返回农历日期对应的公历日期
+func (c *chineseLunarCalendar) toDate(f CalendarFields) (Date, error) {
+	// 第一岁中包含前一年的十一月和十二月
+	if f.Year < lunarMinSui-1 || f.Year >= lunarMaxSui {
+		return Date{}, ErrCalendarOutOfRange
+	}
+	err := ErrInvalidCalendarDate
+	// 十一月, 十二月及其闰月属于下一岁
+	for _, y := range []int{f.Year, f.Year + 1} {
+		months := c.sui(y)
+		if months == nil {
+			err = ErrCalendarOutOfRange
+		}
+		for _, m := range months {
+			if m.year == f.Year && m.number == f.Month && m.leap == f.Leap {
+				if f.Day < 1 || f.Day > m.days {
+					return Date{}, ErrInvalidCalendarDate
+				}
+				return m.start.AddDays(f.Day - 1), nil
+			}
+		}
+	}
+	return Date{}, err
+}
+
+// leapMonth 返回农历年 year 的闰月, 没有闰月时返回 0
+func (c *chineseLunarCalendar) leapMonth(year int) int {
+	for _, y := range []int{year, year + 1} {
+		for _, m := range c.sui(y) {
+			if m.year == year && m.leap {
+				return m.number
+			}
+		}
+	}
+	return 0
+}
+
+// sui 返回第 y 岁的各个月, 超出支持范围时返回 nil
+func (c *chineseLunarCalendar) sui(y int) []lunarMonth {
+	if y < lunarMinSui || y >= lunarMaxSui {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if months, ok := c.suis[y]; ok {
+		return months
+	}
+	months := computeSui(y)
+	c.suis[y] = months
+	return months
+}
+
+// computeSui 计算第 y 岁的各个月
+func computeSui(y int) []lunarMonth {
+	ws1 := solarTermJDE(270, float64(y-1)+0.97)
+	ws2 := solarTermJDE(270, float64(y)+0.97)
+	k1 := newMoonOnOrBefore(chinaDate(ws1))
+	k2 := newMoonOnOrBefore(chinaDate(ws2))
+
+	starts := make([]Date, 0, k2-k1+1)
+	for k := k1; k <= k2; k++ {
+		starts = append(starts, chinaDate(newMoonJDE(float64(k))))
+	}
+	n := k2 - k1
+
+	leap := -1
+	if n == 13 {
+		// 冬至之后的十二个中气, 依次为 300, 330, 0, ..., 270 度
+		var zhongqi []Date
+		jde := ws1
+		for j := 1; j <= 12; j++ {
+			jde = solarTermJDE(math.Mod(270+float64(30*j), 360), jdeYear(jde+30.4))
+			zhongqi = append(zhongqi, chinaDate(jde))
+		}
+		for i := 1; i < n && leap < 0; i++ {
+			has := false
+			for _, z := range zhongqi {
+				if !z.Before(starts[i]) && z.Before(starts[i+1]) {
+					has = true
+					break
+				}
+			}
+			if !has {
+				leap = i
+			}
+		}
+	}
+
+	months := make([]lunarMonth, 0, n)
+	number, year := 11, y-1
+	for i := 0; i < n; i++ {
+		m := lunarMonth{start: starts[i], days: starts[i+1].DaysSince(starts[i])}
+		if i > 0 {
+			if i == leap {
+				m.leap = true
+			} else {
+				number = number%12 + 1
+				if number == 1 {
+					year = y
+				}
+			}
+		}
+		m.number, m.year = number, year
+		months = append(months, m)
+	}
+	return months
+}
+
+// newMoonOnOrBefore 返回朔日不晚于 d 的最后一个朔的序号
+func newMoonOnOrBefore(d Date) int {
+	jd := float64(d.In(time.UTC).Unix())/86400 + julianUnixEpoch
+	k := int(math.Floor((jd - 2451550.09766) / synodicMonth))
+	for chinaDate(newMoonJDE(float64(k+1))).Compare(d) <= 0 {
+		k++
+	}
+	for chinaDate(newMoonJDE(float64(k))).After(d) {
+		k--
+	}
+	return k
+}
+
+// chinaDate 返回力学时儒略日 jde 在东经 120 度标准时间中的日期
+func chinaDate(jde float64) Date {
+	jd := jde - deltaT(jdeYear(jde))/86400
+	sec := (jd - julianUnixEpoch) * 86400
+	return DateOf(time.Unix(int64(math.Floor(sec)), 0).In(chinaStandardTime))
+}
+
+// jdeYear 返回儒略日对应的近似公历年份 (带小数)
+func jdeYear(jde float64) float64 {
+	return 2000 + (jde-2451545)/365.2425
+}
+
+// deltaT 返回力学时与世界时之差的秒数, 使用 Espenak 与 Meeus 给出的多项式近似
+func deltaT(y float64) float64 {
+	switch {
+	case y < 1920:
+		t := y - 1900
+		return -2.79 + 1.494119*t - 0.0598939*t*t + 0.0061966*t*t*t - 0.000197*t*t*t*t
+	case y < 1941:
+		t := y - 1920
+		return 21.20 + 0.84493*t - 0.076100*t*t + 0.0020936*t*t*t
+	case y < 1961:
+		t := y - 1950
+		return 29.07 + 0.407*t - t*t/233 + t*t*t/2547
+	case y < 1986:
+		t := y - 1975
+		return 45.45 + 1.067*t - t*t/260 - t*t*t/718
+	case y < 2005:
+		t := y - 2000
+		return 63.86 + 0.3345*t - 0.060374*t*t + 0.0017275*t*t*t + 0.000651814*t*t*t*t + 0.00002373599*t*t*t*t*t
+	case y < 2050:
+		t := y - 2000
+		return 62.92 + 0.32217*t + 0.005589*t*t
+	default:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+}
+
+// solarTermJDE 返回太阳视黄经在 year 附近 (带小数的年份) 达到 longitude 度的力学时儒略日
+func solarTermJDE(longitude, year float64) float64 {
+	jde := 2451545 + (year-2000)*365.2425
+	for i := 0; i < 50; i++ {
+		diff := math.Mod(longitude-sunApparentLongitude(jde)+540, 360) - 180
+		jde += diff * 365.2425 / 360
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+	return jde
+}
+
+// sunApparentLongitude 返回力学时儒略日 jde 时太阳的视黄经 (度), 精度约 0.01 度 (Meeus 第 25 章)
+func sunApparentLongitude(jde float64) float64 {
+	t := (jde - 2451545) / 36525
+	l0 := 280.46646 + 36000.76983*t + 0.0003032*t*t
+	m := rad(357.52911 + 35999.05029*t - 0.0001537*t*t)
+	c := (1.914602-0.004817*t-0.000014*t*t)*math.Sin(m) + (0.019993-0.000101*t)*math.Sin(2*m) + 0.000289*math.Sin(3*m)
+	omega := rad(125.04 - 1934.136*t)
+	return math.Mod(l0+c-0.00569-0.00478*math.Sin(omega)+360000, 360)
+}
+
+// newMoonJDE 返回第 k 个朔 (k = 0 为 2000 年 1 月 6 日的朔) 的力学时儒略日 (Meeus 第 49 章)
+func newMoonJDE(k float64) float64 {
+	t := k / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+	jde := 2451550.09766 + synodicMonth*k + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+
+	e := 1 - 0.002516*t - 0.0000074*t2
+	m := rad(2.5534 + 29.10535670*k - 0.0000014*t2 - 0.00000011*t3)
+	mp := rad(201.5643 + 385.81693528*k + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4)
+	f := rad(160.7108 + 390.67050284*k - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4)
+	omega := rad(124.7746 - 1.56375588*k + 0.0020672*t2 + 0.00000215*t3)
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	planetary := []struct{ a, b, c, amp float64 }{
+		{299.77, 0.107408, -0.009173, 0.000325},
+		{251.88, 0.016321, 0, 0.000165},
+		{251.83, 26.651886, 0, 0.000164},
+		{349.42, 36.412478, 0, 0.000126},
+		{84.66, 18.206239, 0, 0.000110},
+		{141.74, 53.303771, 0, 0.000062},
+		{207.14, 2.453732, 0, 0.000060},
+		{154.84, 7.306860, 0, 0.000056},
+		{34.52, 27.261239, 0, 0.000047},
+		{207.19, 0.121824, 0, 0.000042},
+		{291.34, 1.844379, 0, 0.000040},
+		{161.72, 24.198154, 0, 0.000037},
+		{239.56, 25.513099, 0, 0.000035},
+		{331.55, 3.592518, 0, 0.000023},
+	}
+	for _, p := range planetary {
+		jde += p.amp * math.Sin(rad(p.a+p.b*k+p.c*t2))
+	}
+	return jde
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
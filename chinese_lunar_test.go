@@ -0,0 +1,151 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChineseLunarKnownDates(t *testing.T) {
+	cases := []struct {
+		date Date
+		want CalendarFields
+	}{
+		// 春节
+		{Date{Year: 1900, Month: time.January, Day: 31}, CalendarFields{Year: 1900, Month: 1, Day: 1}},
+		{Date{Year: 2000, Month: time.February, Day: 5}, CalendarFields{Year: 2000, Month: 1, Day: 1}},
+		{Date{Year: 2020, Month: time.January, Day: 25}, CalendarFields{Year: 2020, Month: 1, Day: 1}},
+		{Date{Year: 2023, Month: time.January, Day: 22}, CalendarFields{Year: 2023, Month: 1, Day: 1}},
+		{Date{Year: 2024, Month: time.February, Day: 10}, CalendarFields{Year: 2024, Month: 1, Day: 1}},
+		{Date{Year: 2025, Month: time.January, Day: 29}, CalendarFields{Year: 2025, Month: 1, Day: 1}},
+		{Date{Year: 2026, Month: time.February, Day: 17}, CalendarFields{Year: 2026, Month: 1, Day: 1}},
+		// 除夕: 2023 年腊月有 30 天, 2024 年腊月只有 29 天
+		{Date{Year: 2024, Month: time.February, Day: 9}, CalendarFields{Year: 2023, Month: 12, Day: 30}},
+		{Date{Year: 2025, Month: time.January, Day: 28}, CalendarFields{Year: 2024, Month: 12, Day: 29}},
+		// 冬至所在的十一月仍属于当年
+		{Date{Year: 2023, Month: time.December, Day: 22}, CalendarFields{Year: 2023, Month: 11, Day: 10}},
+		{Date{Year: 2024, Month: time.June, Day: 10}, CalendarFields{Year: 2024, Month: 5, Day: 5}},
+		{Date{Year: 2024, Month: time.September, Day: 17}, CalendarFields{Year: 2024, Month: 8, Day: 15}},
+		// 闰月
+		{Date{Year: 2020, Month: time.May, Day: 23}, CalendarFields{Year: 2020, Month: 4, Day: 1, Leap: true}},
+		{Date{Year: 2023, Month: time.March, Day: 21}, CalendarFields{Year: 2023, Month: 2, Day: 30}},
+		{Date{Year: 2023, Month: time.March, Day: 22}, CalendarFields{Year: 2023, Month: 2, Day: 1, Leap: true}},
+		{Date{Year: 2023, Month: time.April, Day: 19}, CalendarFields{Year: 2023, Month: 2, Day: 29, Leap: true}},
+		{Date{Year: 2023, Month: time.April, Day: 20}, CalendarFields{Year: 2023, Month: 3, Day: 1}},
+		{Date{Year: 2025, Month: time.July, Day: 25}, CalendarFields{Year: 2025, Month: 6, Day: 1, Leap: true}},
+	}
+	for _, c := range cases {
+		got, err := ChineseLunarCalendar.FromTime(c.date.In(time.UTC), time.UTC)
+		if err != nil || got != c.want {
+			t.Errorf("FromTime(%v) = %+v, %v, want %+v", c.date, got, err, c.want)
+		}
+		back, err := ChineseLunarCalendar.ToTime(c.want, time.UTC)
+		if err != nil || !back.Equal(c.date.In(time.UTC)) {
+			t.Errorf("ToTime(%+v) = %v, %v, want %v", c.want, back, err, c.date)
+		}
+	}
+}
+
+// TestChineseLunarRoundTrip 逐日检查整个支持范围内的农历日期连续, 且每年的月份依次出现, 闰月紧跟在同一月份之后
+func TestChineseLunarRoundTrip(t *testing.T) {
+	c := ChineseLunarCalendar
+	first, last := lunarRange()
+	prev, err := c.FromTime(first.In(time.UTC), time.UTC)
+	if err != nil || prev.Day != 1 {
+		t.Fatalf("FromTime(%v) = %+v, %v", first, prev, err)
+	}
+	if back, err := c.ToTime(prev, time.UTC); err != nil || !back.Equal(first.In(time.UTC)) {
+		t.Fatalf("ToTime(%+v) = %v, %v, want %v", prev, back, err, first)
+	}
+	for d := first.AddDays(1); !d.After(last); d = d.AddDays(1) {
+		f, err := c.FromTime(d.In(time.UTC), time.UTC)
+		if err != nil {
+			t.Fatalf("FromTime(%v): %v", d, err)
+		}
+		back, err := c.ToTime(f, time.UTC)
+		if err != nil || !back.Equal(d.In(time.UTC)) {
+			t.Fatalf("ToTime(%+v) = %v, %v, want %v", f, back, err, d)
+		}
+
+		var ok bool
+		switch {
+		case f.Day > 1:
+			ok = f.Year == prev.Year && f.Month == prev.Month && f.Leap == prev.Leap && f.Day == prev.Day+1
+		case f.Leap:
+			ok = prev.Day >= 29 && !prev.Leap && f.Year == prev.Year && f.Month == prev.Month
+		case f.Month == 1:
+			ok = prev.Day >= 29 && prev.Month == 12 && f.Year == prev.Year+1
+		default:
+			ok = prev.Day >= 29 && f.Year == prev.Year && f.Month == prev.Month+1
+		}
+		if !ok || f.Day > 30 {
+			t.Fatalf("%v: %+v does not follow %+v", d, f, prev)
+		}
+		prev = f
+	}
+}
+
+// lunarRange 返回支持范围的第一天和最后一天
+func lunarRange() (Date, Date) {
+	c := ChineseLunarCalendar.(*chineseLunarCalendar)
+	months := c.sui(lunarMaxSui - 1)
+	last := months[len(months)-1]
+	return c.sui(lunarMinSui)[0].start, last.start.AddDays(last.days - 1)
+}
+
+func TestChineseLunarLeapMonth(t *testing.T) {
+	c := ChineseLunarCalendar.(*chineseLunarCalendar)
+	for year, want := range map[int]int{2017: 6, 2020: 4, 2022: 0, 2023: 2, 2024: 0, 2025: 6, 2028: 5} {
+		if got := c.leapMonth(year); got != want {
+			t.Errorf("leapMonth(%d) = %d, want %d", year, got, want)
+		}
+	}
+
+	for _, f := range []CalendarFields{
+		{Year: 2023, Month: 2, Day: 30, Leap: true},
+		{Year: 2023, Month: 3, Day: 1, Leap: true},
+		{Year: 2024, Month: 2, Day: 1, Leap: true},
+		{Year: 2024, Month: 12, Day: 30},
+		{Year: 2024, Month: 13, Day: 1},
+		{Year: 2024, Month: 1, Day: 0},
+	} {
+		if _, err := ChineseLunarCalendar.ToTime(f, time.UTC); !errors.Is(err, ErrInvalidCalendarDate) {
+			t.Errorf("ToTime(%+v) error = %v, want ErrInvalidCalendarDate", f, err)
+		}
+	}
+}
+
+func TestChineseLunarRange(t *testing.T) {
+	first, last := lunarRange()
+	for _, d := range []Date{{Year: 1800, Month: time.January, Day: 1}, first.AddDays(-1), last.AddDays(1), {Year: 2150, Month: time.January, Day: 1}} {
+		if _, err := ChineseLunarCalendar.FromTime(d.In(time.UTC), time.UTC); !errors.Is(err, ErrCalendarOutOfRange) {
+			t.Errorf("FromTime(%v) error = %v, want ErrCalendarOutOfRange", d, err)
+		}
+	}
+	for _, f := range []CalendarFields{{Year: 1899, Month: 1, Day: 1}, {Year: 2101, Month: 1, Day: 1}, {Year: 1800, Month: 1, Day: 1}} {
+		if _, err := ChineseLunarCalendar.ToTime(f, time.UTC); !errors.Is(err, ErrCalendarOutOfRange) {
+			t.Errorf("ToTime(%+v) error = %v, want ErrCalendarOutOfRange", f, err)
+		}
+	}
+	// 最后一岁之后的十一月和十二月不在支持范围内
+	if f, _ := ChineseLunarCalendar.FromTime(last.In(time.UTC), time.UTC); f.Month >= 11 {
+		t.Fatalf("FromTime(%v) = %+v", last, f)
+	} else if _, err := ChineseLunarCalendar.ToTime(CalendarFields{Year: f.Year, Month: 12, Day: 1}, time.UTC); !errors.Is(err, ErrCalendarOutOfRange) {
+		t.Errorf("ToTime(%d-12-01) error = %v, want ErrCalendarOutOfRange", f.Year, err)
+	}
+}
+
+func TestChineseLunarLocation(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	// UTC 2024-02-09 20:00 在东八区已经是春节
+	at := time.Date(2024, 2, 9, 20, 0, 0, 0, time.UTC)
+	if got, _ := ChineseLunarCalendar.FromTime(at, time.UTC); got != (CalendarFields{Year: 2023, Month: 12, Day: 30}) {
+		t.Errorf("FromTime in UTC = %+v", got)
+	}
+	if got, _ := ChineseLunarCalendar.FromTime(at, shanghai); got != (CalendarFields{Year: 2024, Month: 1, Day: 1}) {
+		t.Errorf("FromTime in CST = %+v", got)
+	}
+	if got, err := ChineseLunarCalendar.ToTime(CalendarFields{Year: 2024, Month: 1, Day: 1}, shanghai); err != nil || !got.Equal(time.Date(2024, 2, 10, 0, 0, 0, 0, shanghai)) {
+		t.Errorf("ToTime in CST = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,336 @@
+package timex
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Clock 抽象了获取当前时间, 计时和等待的能力, 业务代码依赖 Clock 而不是直接调用 time 包, 测试时即可替换为 FakeClock
+type Clock interface {
+	// Now 返回当前时间
+	Now() time.Time
+	// Since 返回从 t 到当前时间经过的时长
+	Since(t time.Time) time.Duration
+	// NewTimer 创建一个在 d 之后触发一次的定时器
+	NewTimer(d time.Duration) Timer
+	// NewTicker 创建一个每隔 d 触发一次的周期定时器, d 必须大于 0
+	NewTicker(d time.Duration) Ticker
+	// Sleep 阻塞至少 d 的时长
+	Sleep(d time.Duration)
+}
+
+// Timer 对应 time.Timer
+type Timer interface {
+	// C 返回定时器触发时接收时间的通道
+	C() <-chan time.Time
+	// Stop 停止定时器, 如果定时器在调用前尚未触发则返回 true
+	Stop() bool
+	// Reset 将定时器改为在 d 之后触发, 如果定时器在调用前尚未触发则返回 true
+	Reset(d time.Duration) bool
+}
+
+// Ticker 对应 time.Ticker
+type Ticker interface {
+	// C 返回每次触发时接收时间的通道
+	C() <-chan time.Time
+	// Stop 停止周期定时器
+	Stop()
+	// Reset 停止周期定时器并将周期改为 d
+	Reset(d time.Duration)
+}
+
+// RealClock 是基于 time 包的 Clock 实现
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                  { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration { return time.Since(t) }
+func (realClock) Sleep(d time.Duration)           { time.Sleep(d) }
+func (realClock) NewTimer(d time.Duration) Timer  { return realTimer{time.NewTimer(d)} }
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time   { return t.t.C }
+func (t realTicker) Stop()                 { t.t.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.t.Reset(d) }
+
+// FakeClock 是可以手动控制的 Clock 实现, 用于编写确定性的测试.
+// 时间只会在调用 Advance 或 Set 时前进, 定时器在时间到达其触发时刻时按时间顺序触发, 触发时发送的是其计划触发时刻.
+type FakeClock struct {
+	mu      sync.Mutex
+	changed *sync.Cond // 等待中的定时器数量变化时广播
+	now     time.Time
+	timers  []*fakeTimer
+}
+
+// NewFakeClock 创建FakeClock, 其初始时间为 now
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.changed = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now 返回当前的模拟时间
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since 返回从 t 到当前模拟时间经过的时长
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// NewTimer 创建一个在模拟时间经过 d 之后触发的定时器, d 不大于 0 时立即触发
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// NewTicker 创建一个每隔 d 的模拟时间触发一次的周期定时器
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timex: non-positive interval for NewTicker")
+	}
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1), period: d}
+	t.Reset(d)
+	return fakeTicker{t}
+}
+
+// Sleep 阻塞直到模拟时间经过 d, 需要由其他 goroutine 调用 Advance 或 Set 推进时间
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// Advance 将模拟时间推进 d, 并触发期间到期的定时器
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set 将模拟时间设置为 t, 并触发期间到期的定时器. t 早于当前模拟时间时只修改时间, 不会触发定时器.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fireUntil(t)
+	c.now = t
+}
+
+// AdvanceToNext 将模拟时间推进到最早一个等待中的定时器的触发时刻并触发它, 没有等待中的定时器时返回 false
+func (c *FakeClock) AdvanceToNext() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.timers) == 0 {
+		return false
+	}
+	next := c.timers[0].deadline
+	c.fireUntil(next)
+	if next.After(c.now) {
+		c.now = next
+	}
+	return true
+}
+
+// PendingTimers 返回等待触发的定时器数量, 周期定时器在停止前一直计入
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntil 阻塞直到等待触发的定时器数量不少于 n.
+// 测试中可以用它等待被测 goroutine 进入 Sleep 或创建定时器之后再推进时间, 避免竞态.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.changed.Wait()
+	}
+}
+
+// fireUntil 按时间顺序触发所有不晚于 t 的定时器, 调用方需要持有锁
+func (c *FakeClock) fireUntil(t time.Time) {
+	for len(c.timers) > 0 && !c.timers[0].deadline.After(t) {
+		timer := c.timers[0]
+		if timer.deadline.After(c.now) {
+			c.now = timer.deadline
+		}
+		c.removeTimer(timer)
+		select {
+		case timer.ch <- timer.deadline:
+		default:
+		}
+		if timer.period > 0 {
+			timer.deadline = timer.deadline.Add(timer.period)
+			c.addTimer(timer)
+		}
+	}
+}
+
+// addTimer 将定时器按触发时刻插入有序列表, 调用方需要持有锁
+func (c *FakeClock) addTimer(t *fakeTimer) {
+	i, _ := slices.BinarySearchFunc(c.timers, t.deadline, func(e *fakeTimer, d time.Time) int {
+		if e.deadline.After(d) {
+			return 1
+		}
+		return -1
+	})
+	c.timers = slices.Insert(c.timers, i, t)
+	c.changed.Broadcast()
+}
+
+// removeTimer 移除定时器, 返回定时器是否仍在等待触发, 调用方需要持有锁
+func (c *FakeClock) removeTimer(t *fakeTimer) bool {
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	c.changed.Broadcast()
+	return true
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration // 大于 0 时表示周期定时器
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.removeTimer(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	active := c.removeTimer(t)
+	t.deadline = c.now.Add(d)
+	c.addTimer(t)
+	c.fireUntil(c.now)
+	return active
+}
+
+type fakeTicker struct {
+	t *fakeTimer
+}
+
+func (t fakeTicker) C() <-chan time.Time {
+	return t.t.ch
+}
+
+func (t fakeTicker) Stop() {
+	t.t.Stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("timex: non-positive interval for Ticker.Reset")
+	}
+	t.t.clock.mu.Lock()
+	t.t.period = d
+	t.t.clock.mu.Unlock()
+	t.t.Reset(d)
+}
+
+// FrozenClock 返回一个时间永远停在 t 的 Clock, 适合模拟某个特定时刻 (如 "下周二上午") 下的行为.
+// 它的定时器只有在时长不大于 0 时才会立即触发, 否则永远不会触发; Sleep 立即返回.
+func FrozenClock(t time.Time) Clock {
+	return frozenClock{t: t}
+}
+
+type frozenClock struct {
+	t time.Time
+}
+
+func (c frozenClock) Now() time.Time                  { return c.t }
+func (c frozenClock) Since(t time.Time) time.Duration { return c.t.Sub(t) }
+func (c frozenClock) Sleep(time.Duration)             {}
+func (c frozenClock) NewTimer(d time.Duration) Timer {
+	t := &frozenTimer{clock: c, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+func (c frozenClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timex: non-positive interval for NewTicker")
+	}
+	return frozenTicker{ch: make(chan time.Time)}
+}
+
+type frozenTimer struct {
+	clock  frozenClock
+	ch     chan time.Time
+	mu     sync.Mutex
+	active bool
+}
+
+func (t *frozenTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *frozenTimer) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+func (t *frozenTimer) Reset(d time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	active := t.active
+	t.active = d > 0
+	if d <= 0 {
+		select {
+		case t.ch <- t.clock.t:
+		default:
+		}
+	}
+	return active
+}
+
+type frozenTicker struct {
+	ch chan time.Time
+}
+
+func (t frozenTicker) C() <-chan time.Time { return t.ch }
+func (t frozenTicker) Stop()               {}
+func (t frozenTicker) Reset(time.Duration) {}
+
+// OffsetClock 返回一个比 base 快 offset 的 Clock (offset 为负数时则是慢), 定时器和 Sleep 直接使用 base 的实现.
+// 可以用来模拟时钟偏差, 或者在真实的时间流逝下模拟未来某一天的运行情况.
+func OffsetClock(base Clock, offset time.Duration) Clock {
+	return offsetClock{base: base, offset: offset}
+}
+
+type offsetClock struct {
+	base   Clock
+	offset time.Duration
+}
+
+func (c offsetClock) Now() time.Time                   { return c.base.Now().Add(c.offset) }
+func (c offsetClock) Since(t time.Time) time.Duration  { return c.base.Since(t) + c.offset }
+func (c offsetClock) Sleep(d time.Duration)            { c.base.Sleep(d) }
+func (c offsetClock) NewTimer(d time.Duration) Timer   { return c.base.NewTimer(d) }
+func (c offsetClock) NewTicker(d time.Duration) Ticker { return c.base.NewTicker(d) }
@@ -0,0 +1,147 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+// receiveTime 非阻塞地从通道读取一个时间
+func receiveTime(ch <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-ch:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFakeClockTimer(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	timer := c.NewTimer(time.Minute)
+
+	c.Advance(59 * time.Second)
+	if _, ok := receiveTime(timer.C()); ok {
+		t.Fatal("timer fired before its deadline")
+	}
+	if c.PendingTimers() != 1 {
+		t.Fatalf("PendingTimers = %d, want 1", c.PendingTimers())
+	}
+	c.Advance(2 * time.Second)
+	if at, ok := receiveTime(timer.C()); !ok || !at.Equal(t0.Add(time.Minute)) {
+		t.Fatalf("timer fired with %v, %v, want %v", at, ok, t0.Add(time.Minute))
+	}
+	if got := c.Now(); !got.Equal(t0.Add(61 * time.Second)) {
+		t.Errorf("Now = %v, want %v", got, t0.Add(61*time.Second))
+	}
+	if got := c.Since(t0); got != 61*time.Second {
+		t.Errorf("Since = %v, want 61s", got)
+	}
+
+	if timer.Reset(time.Hour) {
+		t.Error("Reset of a fired timer should report false")
+	}
+	if !timer.Stop() {
+		t.Error("Stop of a pending timer should report true")
+	}
+	c.Advance(2 * time.Hour)
+	if _, ok := receiveTime(timer.C()); ok {
+		t.Error("stopped timer fired")
+	}
+	if _, ok := receiveTime(c.NewTimer(0).C()); !ok {
+		t.Error("timer with non-positive duration should fire immediately")
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	ticker := c.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	var got []time.Time
+	for i := 0; i < 3; i++ {
+		c.Advance(10 * time.Second)
+		if at, ok := receiveTime(ticker.C()); ok {
+			got = append(got, at)
+		}
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d ticks, want 3", len(got))
+	}
+	for i, at := range got {
+		if want := t0.Add(time.Duration(i+1) * 10 * time.Second); !at.Equal(want) {
+			t.Errorf("tick %d = %v, want %v", i, at, want)
+		}
+	}
+
+	ticker.Reset(time.Minute)
+	c.Advance(30 * time.Second)
+	if _, ok := receiveTime(ticker.C()); ok {
+		t.Error("ticker fired before the new period elapsed")
+	}
+	c.Advance(30 * time.Second)
+	if _, ok := receiveTime(ticker.C()); !ok {
+		t.Error("ticker did not fire after Reset period")
+	}
+}
+
+func TestFakeClockAdvanceToNext(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	late, early := c.NewTimer(time.Hour), c.NewTimer(time.Minute)
+
+	if !c.AdvanceToNext() {
+		t.Fatal("AdvanceToNext = false with pending timers")
+	}
+	if !c.Now().Equal(t0.Add(time.Minute)) {
+		t.Errorf("Now = %v, want %v", c.Now(), t0.Add(time.Minute))
+	}
+	if _, ok := receiveTime(early.C()); !ok {
+		t.Error("earliest timer did not fire")
+	}
+	if _, ok := receiveTime(late.C()); ok {
+		t.Error("later timer fired too early")
+	}
+	c.AdvanceToNext()
+	if c.AdvanceToNext() {
+		t.Error("AdvanceToNext = true with no pending timers")
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	done := make(chan time.Time)
+	go func() {
+		c.Sleep(time.Hour)
+		done <- c.Now()
+	}()
+
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+	if woke := <-done; !woke.Equal(t0.Add(time.Hour)) {
+		t.Errorf("Sleep returned at %v, want %v", woke, t0.Add(time.Hour))
+	}
+}
+
+func TestFrozenAndOffsetClock(t *testing.T) {
+	t0 := time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)
+	frozen := FrozenClock(t0)
+	if !frozen.Now().Equal(t0) || frozen.Since(t0.Add(-time.Minute)) != time.Minute {
+		t.Errorf("FrozenClock Now = %v", frozen.Now())
+	}
+	if _, ok := receiveTime(frozen.NewTimer(time.Second).C()); ok {
+		t.Error("FrozenClock timer with positive duration fired")
+	}
+
+	fake := NewFakeClock(t0)
+	offset := OffsetClock(fake, -time.Hour)
+	if !offset.Now().Equal(t0.Add(-time.Hour)) {
+		t.Errorf("OffsetClock Now = %v, want %v", offset.Now(), t0.Add(-time.Hour))
+	}
+	fake.Advance(time.Minute)
+	if !offset.Now().Equal(t0.Add(-59 * time.Minute)) {
+		t.Errorf("OffsetClock did not follow its base clock: %v", offset.Now())
+	}
+}
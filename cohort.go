@@ -0,0 +1,56 @@
+package timex
+
+import (
+	"strconv"
+	"time"
+)
+
+// CohortOf 返回 signup 在 loc 中所属 unit 周期的名称, 用作同期群分析的分组键. 各单位的格式为:
+// 年 "2024", 季度 "2024-Q1", 月 "2024-01", 周 "2024-W03" (ISO 周), 日 "2024-01-15",
+// 时 "2024-01-15T10", 分 "2024-01-15T10:30", 秒 "2024-01-15T10:30:45". 无效的 unit 按 UnitDay 处理.
+func CohortOf(signup time.Time, unit Unit, loc *time.Location) string {
+	switch unit {
+	case UnitYear:
+		return strconv.Itoa(signup.In(loc).Year())
+	case UnitQuarter:
+		return QuarterOfByTz(signup, loc).String()
+	case UnitMonth:
+		return YearMonthOfByTz(signup, loc).String()
+	case UnitWeek:
+		return ISOWeekOfByTz(signup, loc).String()
+	case UnitHour:
+		return TruncateTo(signup, unit, loc).Format("2006-01-02T15")
+	case UnitMinute:
+		return TruncateTo(signup, unit, loc).Format("2006-01-02T15:04")
+	case UnitSecond:
+		return TruncateTo(signup, unit, loc).Format("2006-01-02T15:04:05")
+	default:
+		return DateOfByTz(signup, loc).String()
+	}
+}
+
+// PeriodsSince 返回在 loc 中从 signup 所属的 unit 周期到 at 所属的 unit 周期之间相隔的周期数, 同一周期内为 0, at 所属周期较早时为负数.
+// 例如 UnitWeek 时, 注册当周为第 0 周, 即使注册发生在周日, 次日周一也已是第 1 周.
+func PeriodsSince(signup, at time.Time, unit Unit, loc *time.Location) int {
+	if d := unit.fixedDuration(); d > 0 {
+		return int(TruncateTo(at, unit, loc).Sub(TruncateTo(signup, unit, loc)) / d)
+	}
+	s, a := DateOfByTz(signup, loc), DateOfByTz(at, loc)
+	switch unit {
+	case UnitYear:
+		return a.Year - s.Year
+	case UnitQuarter:
+		return floorDiv(monthIndex(a), 3) - floorDiv(monthIndex(s), 3)
+	case UnitMonth:
+		return monthIndex(a) - monthIndex(s)
+	case UnitWeek:
+		return truncateDate(a, UnitWeek).DaysSince(truncateDate(s, UnitWeek)) / 7
+	default:
+		return a.DaysSince(s)
+	}
+}
+
+// monthIndex 返回日期所在月份从公元 0 年 1 月开始的序号
+func monthIndex(d Date) int {
+	return d.Year*12 + int(d.Month) - 1
+}
@@ -0,0 +1,173 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// QuorumWindow 返回至少有 quorum 个输入范围同时覆盖的时间段, 结果按时间排序且互不重叠, 相邻的时间段会被合并.
+// 例如 5 个评审者各自的空闲时间, 找出至少 3 人同时有空的时间段. 只在单个时间点上满足条件 (如两个闭区间首尾相接) 的情况会被忽略.
+// quorum 小于 1 时按 1 处理, 此时结果即为所有输入范围的并集.
+func QuorumWindow(votes []*TimeRange, quorum int) []*TimeRange {
+	if quorum < 1 {
+		quorum = 1
+	}
+	return depthRuns(depthProfile(votes), func(depth int) bool { return depth >= quorum }, false)
+}
+
+// DepthSegment 表示一段被固定数量的时间范围覆盖的时间
+type DepthSegment struct {
+	Range *TimeRange
+	Depth int // 覆盖该时间段的范围个数, 0 表示无人覆盖
+}
+
+// CoverageByDepth 将 bound 划分为若干首尾相接的时间段, 并标注每段被 ranges 中多少个范围覆盖, 相邻且深度相同的时间段会被合并.
+// 结果按时间排序, 完整覆盖 bound 且互不重叠, 因此可以据此找出无人覆盖 (Depth 为 0) 或只有单人覆盖 (Depth 为 1) 的时间段.
+// 当某个时间点的覆盖深度与两侧都不同时 (如两个闭区间首尾相接), 该时间点会单独成为一段.
+func CoverageByDepth(bound *TimeRange, ranges []*TimeRange) []DepthSegment {
+	pieces := depthProfile(append([]*TimeRange{bound}, ranges...))
+	var result []DepthSegment
+	for i := 0; i < len(pieces); {
+		if !pieceInRange(pieces[i], bound) {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(pieces) && pieceInRange(pieces[j+1], bound) && pieces[j+1].depth == pieces[i].depth {
+			j++
+		}
+		// depthProfile 的计算中包含了 bound 本身, 需要减去
+		result = append(result, DepthSegment{Range: piecesToTimeRange(pieces[i], pieces[j]), Depth: pieces[i].depth - 1})
+		i = j + 1
+	}
+	return result
+}
+
+// FitsCapacity 判断在已有的时间范围 (如已确认的预订) 之外再加入 candidate 后, 任意时刻的并发数是否都不超过 capacity.
+// 不满足时同时返回 candidate 中会超出容量的时间段, 按时间排序且互不重叠, 其中也包括只在单个时间点上超出的情况 (如闭区间首尾相接).
+func FitsCapacity(existing []*TimeRange, candidate *TimeRange, capacity int) (bool, []*TimeRange) {
+	var pieces []depthPiece
+	for _, p := range depthProfile(append([]*TimeRange{candidate}, existing...)) {
+		if pieceInRange(p, candidate) {
+			pieces = append(pieces, p)
+		}
+	}
+	over := depthRuns(pieces, func(depth int) bool { return depth > capacity }, true)
+	return len(over) == 0, over
+}
+
+// pieceInRange 判断分段是否完全落在 tr 中
+func pieceInRange(p depthPiece, tr *TimeRange) bool {
+	if p.point {
+		return tr.Contains(p.start)
+	}
+	return !p.start.Before(tr.start) && !p.end.After(tr.end)
+}
+
+// SelfTimes 计算父级时间范围 (如 trace 中的一个 span) 的自身耗时, 即未被任何子范围覆盖的时长, 以及被子范围覆盖的时长.
+// 子范围之间重叠的部分只计算一次, 超出父级范围的部分会被裁剪, self 与 coveredChildren 之和总是等于父级范围的时长.
+func SelfTimes(parent *TimeRange, children []*TimeRange) (self time.Duration, coveredChildren time.Duration) {
+	for _, seg := range CoverageByDepth(parent, children) {
+		if seg.Depth > 0 {
+			coveredChildren += seg.Range.end.Sub(seg.Range.start)
+		}
+	}
+	return parent.end.Sub(parent.start) - coveredChildren, coveredChildren
+}
+
+// depthPiece 表示覆盖深度恒定的一段时间, 它要么是单个时间点, 要么是两个相邻边界之间的开区间
+type depthPiece struct {
+	start time.Time
+	end   time.Time
+	point bool // 为 true 时表示单个时间点 start, 此时 start 与 end 相同
+	depth int  // 覆盖该段时间的范围个数
+}
+
+// depthProfile 计算一组时间范围的覆盖深度分布.
+// 所有范围的端点排序去重后, 依次交替输出端点本身和相邻端点之间的开区间, 各段的开闭性都已考虑在内, 因此首尾都是单个时间点.
+func depthProfile(ranges []*TimeRange) []depthPiece {
+	bounds := make([]time.Time, 0, len(ranges)*2)
+	for _, r := range ranges {
+		if r != nil {
+			bounds = append(bounds, r.start, r.end)
+		}
+	}
+	if len(bounds) == 0 {
+		return nil
+	}
+	slices.SortFunc(bounds, func(a, b time.Time) int { return a.Compare(b) })
+	bounds = slices.CompactFunc(bounds, func(a, b time.Time) bool { return a.Equal(b) })
+	indexOf := func(t time.Time) int {
+		i, _ := slices.BinarySearchFunc(bounds, t, func(a, b time.Time) int { return a.Compare(b) })
+		return i
+	}
+
+	n := len(bounds)
+	pointDiff, segDiff, pointExtra := make([]int, n+1), make([]int, n+1), make([]int, n)
+	for _, r := range ranges {
+		if r == nil {
+			continue
+		}
+		s, e := indexOf(r.start), indexOf(r.end)
+		if s == e {
+			if r.startInclusive && r.endInclusive {
+				pointExtra[s]++
+			}
+			continue
+		}
+		segDiff[s]++
+		segDiff[e]--
+		if e > s+1 {
+			pointDiff[s+1]++
+			pointDiff[e]--
+		}
+		if r.startInclusive {
+			pointExtra[s]++
+		}
+		if r.endInclusive {
+			pointExtra[e]++
+		}
+	}
+
+	pieces := make([]depthPiece, 0, 2*n-1)
+	pointDepth, segDepth := 0, 0
+	for i := 0; i < n; i++ {
+		pointDepth += pointDiff[i]
+		pieces = append(pieces, depthPiece{start: bounds[i], end: bounds[i], point: true, depth: pointDepth + pointExtra[i]})
+		if i+1 < n {
+			segDepth += segDiff[i]
+			pieces = append(pieces, depthPiece{start: bounds[i], end: bounds[i+1], depth: segDepth})
+		}
+	}
+	return pieces
+}
+
+// depthRuns 将满足 keep 条件的连续分段合并为时间范围. keepPoints 为 false 时, 只由单个时间点构成的结果会被丢弃.
+func depthRuns(pieces []depthPiece, keep func(depth int) bool, keepPoints bool) []*TimeRange {
+	var result []*TimeRange
+	for i := 0; i < len(pieces); {
+		if !keep(pieces[i].depth) {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < len(pieces) && keep(pieces[j+1].depth) {
+			j++
+		}
+		if i != j || !pieces[i].point || keepPoints {
+			result = append(result, piecesToTimeRange(pieces[i], pieces[j]))
+		}
+		i = j + 1
+	}
+	return result
+}
+
+// piecesToTimeRange 返回从 first 开始到 last 结束的时间范围
+func piecesToTimeRange(first, last depthPiece) *TimeRange {
+	return &TimeRange{
+		start:          first.start,
+		end:            last.end,
+		startInclusive: first.point,
+		endInclusive:   last.point,
+	}
+}
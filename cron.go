@@ -0,0 +1,251 @@
+package timex
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCron 表示无法解析的 cron 表达式
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// cronSearchDays 是查找触发时刻时最多向前或向后检查的天数, 足以覆盖 "0 0 29 2 *" 这样最多八年才触发一次的表达式
+const cronSearchDays = 366 * 9
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	cronMonthNames   = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronWeekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// CronSchedule 是由 cron 表达式描述的 Schedule.
+// 因夏令时切换而不存在的墙上时刻不会触发, 出现两次的墙上时刻只在较早的一次触发.
+type CronSchedule struct {
+	spec    string
+	second  uint64
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool // 日期字段为 * 或 ?
+	dowStar bool // 星期字段为 * 或 ?
+	loc     *time.Location
+}
+
+// ParseCron 解析 cron 表达式, 触发时刻按照传入时间 (Next 的 after, Prev 的 before, OccurrencesIn 的开始时间) 自身的时区计算.
+// 支持标准的 5 个字段 "分 时 日 月 星期", 以及在最前面加上秒的 6 个字段; 每个字段支持 *, ?, 列表 (1,15), 范围 (1-5) 和步长 (*/10, 8-18/2),
+// 月份和星期可以使用英文缩写 (JAN, MON), 星期的 0 和 7 都表示周日; 另外支持 @yearly, @monthly, @weekly, @daily 和 @hourly.
+// 与标准 cron 一致, 日期和星期字段都不是 * 时, 满足其一即可触发.
+func ParseCron(spec string) (*CronSchedule, error) {
+	return ParseCronByTz(spec, nil)
+}
+
+// ParseCronByTz 解析 cron 表达式, 触发时刻按照 loc 计算, loc 为 nil 时与 ParseCron 相同
+func ParseCronByTz(spec string, loc *time.Location) (*CronSchedule, error) {
+	expr := strings.TrimSpace(spec)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, ErrInvalidCron
+	}
+
+	c := &CronSchedule{spec: spec, loc: loc}
+	var err error
+	if c.second, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.minute, err = parseCronField(fields[1], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hour, err = parseCronField(fields[2], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.dom, err = parseCronField(fields[3], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if c.month, err = parseCronField(fields[4], 1, 12, cronMonthNames); err != nil {
+		return nil, err
+	}
+	if c.dow, err = parseCronField(fields[5], 0, 7, cronWeekdayNames); err != nil {
+		return nil, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domStar = fields[3][0] == '*' || fields[3][0] == '?'
+	c.dowStar = fields[5][0] == '*' || fields[5][0] == '?'
+	return c, nil
+}
+
+// MustParseCron 解析 cron 表达式, 如果表达式无效则 panic
+func MustParseCron(spec string) *CronSchedule {
+	c, err := ParseCron(spec)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String 返回原始的 cron 表达式
+func (c *CronSchedule) String() string {
+	return c.spec
+}
+
+// Next 返回晚于 after 的第一个触发时刻, 实现了 Schedule
+func (c *CronSchedule) Next(after time.Time) (time.Time, bool) {
+	loc := c.location(after)
+	d := DateOfByTz(after, loc)
+	for i := 0; i < cronSearchDays; i, d = i+1, d.AddDays(1) {
+		if !c.matchDate(d) {
+			continue
+		}
+		for h := 0; h < 24; h++ {
+			if c.hour&(1<<h) == 0 || !resolveLocal(d.Year, d.Month, d.Day, h, 59, 59, 0, loc).After(after) {
+				continue
+			}
+			for m := 0; m < 60; m++ {
+				if c.minute&(1<<m) == 0 || !resolveLocal(d.Year, d.Month, d.Day, h, m, 59, 0, loc).After(after) {
+					continue
+				}
+				for s := 0; s < 60; s++ {
+					if c.second&(1<<s) == 0 {
+						continue
+					}
+					if t, ok := cronAt(d, h, m, s, loc); ok && t.After(after) {
+						return t, true
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// Prev 返回早于 before 的最后一个触发时刻
+func (c *CronSchedule) Prev(before time.Time) (time.Time, bool) {
+	loc := c.location(before)
+	d := DateOfByTz(before, loc)
+	for i := 0; i < cronSearchDays; i, d = i+1, d.AddDays(-1) {
+		if !c.matchDate(d) {
+			continue
+		}
+		for h := 23; h >= 0; h-- {
+			if c.hour&(1<<h) == 0 || !resolveLocal(d.Year, d.Month, d.Day, h, 0, 0, 0, loc).Before(before) {
+				continue
+			}
+			for m := 59; m >= 0; m-- {
+				if c.minute&(1<<m) == 0 || !resolveLocal(d.Year, d.Month, d.Day, h, m, 0, 0, loc).Before(before) {
+					continue
+				}
+				for s := 59; s >= 0; s-- {
+					if c.second&(1<<s) == 0 {
+						continue
+					}
+					if t, ok := cronAt(d, h, m, s, loc); ok && t.Before(before) {
+						return t, true
+					}
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// OccurrencesIn 依次迭代落在时间范围内的触发时刻
+func (c *CronSchedule) OccurrencesIn(tr *TimeRange) iter.Seq[time.Time] {
+	return OccurrencesIn(c, tr, 0)
+}
+
+func (c *CronSchedule) location(t time.Time) *time.Location {
+	if c.loc != nil {
+		return c.loc
+	}
+	return t.Location()
+}
+
+func (c *CronSchedule) matchDate(d Date) bool {
+	if c.month&(1<<d.Month) == 0 {
+		return false
+	}
+	domMatch, dowMatch := c.dom&(1<<d.Day) != 0, c.dow&(1<<d.Weekday()) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// cronAt 返回 d 的墙上时刻 h:m:s 在 loc 中对应的时间点, 该墙上时刻不存在时返回 false
+func cronAt(d Date, h, m, s int, loc *time.Location) (time.Time, bool) {
+	t := resolveLocal(d.Year, d.Month, d.Day, h, m, s, 0, loc)
+	return t, DateOf(t) == d && t.Hour() == h && t.Minute() == m && t.Second() == s
+}
+
+// parseCronField 解析 cron 表达式的一个字段, 返回取值的位集合. names 不为 nil 时, names[i] 表示取值 min+i.
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, ErrInvalidCron
+			}
+		}
+
+		lo, hi := min, max
+		if expr != "*" && expr != "?" {
+			loStr, hiStr, isRange := strings.Cut(expr, "-")
+			var ok bool
+			if lo, ok = parseCronValue(loStr, min, max, names); !ok {
+				return 0, ErrInvalidCron
+			}
+			hi = lo
+			if isRange {
+				if hi, ok = parseCronValue(hiStr, min, max, names); !ok || hi < lo {
+					return 0, ErrInvalidCron
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+	if set == 0 {
+		return 0, ErrInvalidCron
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, min, max int, names []string) (int, bool) {
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return min + i, true
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,150 @@
+package timex
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	// 2024-04-26 是周五
+	after := time.Date(2024, 4, 26, 10, 17, 30, 0, time.UTC)
+	at := func(mo time.Month, d, h, m, s int) time.Time { return time.Date(2024, mo, d, h, m, s, 0, time.UTC) }
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", at(4, 26, 10, 18, 0)},
+		{"*/15 * * * *", at(4, 26, 10, 30, 0)},
+		{"0 9-17/4 * * *", at(4, 26, 13, 0, 0)},
+		{"0 0 1,15 * *", at(5, 1, 0, 0, 0)},
+		{"0 9 * * MON-FRI", at(4, 29, 9, 0, 0)},
+		{"0 9 * * mon", at(4, 29, 9, 0, 0)},
+		{"0 0 * * 0", at(4, 28, 0, 0, 0)},
+		{"0 0 * * 7", at(4, 28, 0, 0, 0)},
+		{"0 0 1 JAN *", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 ? 6 *", at(6, 1, 0, 0, 0)},
+		// 日期和星期都不是 * 时满足其一即可: 每月 13 日或者周五
+		{"0 0 13 * FRI", at(5, 3, 0, 0, 0)},
+		{"0 0 * * FRI", at(5, 3, 0, 0, 0)},
+		{"0 12 13 * 5", at(4, 26, 12, 0, 0)},
+		// 6 个字段时第一个为秒
+		{"45 17 10 * * *", at(4, 26, 10, 17, 45)},
+		{"*/20 * * * * *", at(4, 26, 10, 17, 40)},
+		{"@hourly", at(4, 26, 11, 0, 0)},
+		{"@daily", at(4, 27, 0, 0, 0)},
+		{"@midnight", at(4, 27, 0, 0, 0)},
+		{"@weekly", at(4, 28, 0, 0, 0)},
+		{"@monthly", at(5, 1, 0, 0, 0)},
+		{"@YEARLY", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"@annually", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		s, err := ParseCron(c.spec)
+		if err != nil {
+			t.Errorf("ParseCron(%q): %v", c.spec, err)
+			continue
+		}
+		if got, ok := s.Next(after); !ok || !got.Equal(c.want) {
+			t.Errorf("%q.Next(%v) = %v, %v, want %v", c.spec, after, got, ok, c.want)
+		}
+		if s.String() != c.spec {
+			t.Errorf("String() = %q, want %q", s.String(), c.spec)
+		}
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	before := time.Date(2024, 4, 26, 10, 17, 30, 0, time.UTC)
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 4, 26, 10, 17, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC)},
+		{"0 18 * * MON-FRI", time.Date(2024, 4, 25, 18, 0, 0, 0, time.UTC)},
+		{"*/20 * * * * *", time.Date(2024, 4, 26, 10, 17, 20, 0, time.UTC)},
+		{"@yearly", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got, ok := MustParseCron(c.spec).Prev(before); !ok || !got.Equal(c.want) {
+			t.Errorf("%q.Prev(%v) = %v, %v, want %v", c.spec, before, got, ok, c.want)
+		}
+	}
+}
+
+func TestCronLeapDaySearchBound(t *testing.T) {
+	s := MustParseCron("0 0 29 2 *")
+	// 2100 年不是闰年, 从 2097 年起下一个 2 月 29 日在 2104 年
+	if got, ok := s.Next(time.Date(2097, 3, 1, 0, 0, 0, 0, time.UTC)); !ok || !got.Equal(time.Date(2104, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next = %v, %v, want 2104-02-29", got, ok)
+	}
+	if got, ok := s.Prev(time.Date(2104, 2, 1, 0, 0, 0, 0, time.UTC)); !ok || !got.Equal(time.Date(2096, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Prev = %v, %v, want 2096-02-29", got, ok)
+	}
+	if _, ok := MustParseCron("0 0 30 2 *").Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Error(`"0 0 30 2 *" should never fire`)
+	}
+}
+
+func TestCronDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// 2024-03-10 02:00-03:00 被跳过, 当天 02:30 不触发
+	gap := MustParseCron("30 2 * * *")
+	if got, ok := gap.Next(time.Date(2024, 3, 10, 0, 0, 0, 0, ny)); !ok || !got.Equal(time.Date(2024, 3, 11, 2, 30, 0, 0, ny)) {
+		t.Errorf("gap Next = %v, %v, want 2024-03-11 02:30", got, ok)
+	}
+	if got, ok := gap.Prev(time.Date(2024, 3, 11, 0, 0, 0, 0, ny)); !ok || !got.Equal(time.Date(2024, 3, 9, 2, 30, 0, 0, ny)) {
+		t.Errorf("gap Prev = %v, %v, want 2024-03-09 02:30", got, ok)
+	}
+
+	// 2024-11-03 01:00-02:00 出现两次, 01:30 只在较早的 EDT 触发一次
+	fold := MustParseCron("30 1 * * *")
+	first, ok := fold.Next(time.Date(2024, 11, 3, 0, 0, 0, 0, ny))
+	if want := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC); !ok || !first.Equal(want) {
+		t.Fatalf("fold Next = %v, %v, want %v", first, ok, want)
+	}
+	if got, ok := fold.Next(first); !ok || !got.Equal(time.Date(2024, 11, 4, 1, 30, 0, 0, ny)) {
+		t.Errorf("fold fired again on the same day: %v, %v", got, ok)
+	}
+
+	// ParseCronByTz 按照指定时区计算, 与传入时间的时区无关
+	s, err := ParseCronByTz("0 9 * * *", ny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := s.Next(time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC)); !ok || !got.Equal(time.Date(2024, 4, 26, 9, 0, 0, 0, ny)) {
+		t.Errorf("ParseCronByTz Next = %v, %v", got, ok)
+	}
+}
+
+func TestCronOccurrencesIn(t *testing.T) {
+	tr := MustNewTimeRange(time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC), time.Date(2024, 4, 26, 10, 0, 0, 0, time.UTC), true, false)
+	got := collectTimes(MustParseCron("*/20 * * * *").OccurrencesIn(tr))
+	want := []time.Time{
+		time.Date(2024, 4, 26, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 26, 9, 20, 0, 0, time.UTC),
+		time.Date(2024, 4, 26, 9, 40, 0, 0, time.UTC),
+	}
+	if !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("OccurrencesIn = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * 32 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "*/x * * * *", "a * * * *",
+		"* * * FOO *", "1,,2 * * * *", "@every",
+	} {
+		if _, err := ParseCron(spec); !errors.Is(err, ErrInvalidCron) {
+			t.Errorf("ParseCron(%q) error = %v, want ErrInvalidCron", spec, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package timex
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// Crossing 表示当前时间越过了某个时间点
+type Crossing struct {
+	At    time.Time // 被越过的时间点
+	Index int       // 该时间点在输入中的下标
+	Late  bool      // 为 true 时表示开始监听时该时间点已经过去, 这是一次补发的通知
+}
+
+// Crossings 监听 clock 的当前时间, 每越过 instants 中的一个时间点就通过返回的通道发送一次通知, 通知按时间点的先后顺序发送, 相同的时间点按下标顺序发送.
+// 开始监听时已经过去的时间点会立即补发, 但不晚于 since 的时间点视为已经处理过, 不会补发; 可以将 since 设为上次处理到的时间点, 以便重启后补发期间错过的通知.
+// 所有通知发送完毕或 ctx 被取消后通道关闭. 通道没有缓冲, 接收方处理缓慢时后续通知会推迟发送, 但不会丢失.
+func Crossings(ctx context.Context, clock Clock, instants []time.Time, since time.Time) <-chan Crossing {
+	order := make([]int, len(instants))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return instants[a].Compare(instants[b])
+	})
+
+	ch := make(chan Crossing)
+	go func() {
+		defer close(ch)
+		start := clock.Now()
+		for _, i := range order {
+			t := instants[i]
+			if !t.After(since) {
+				continue
+			}
+			late := !t.After(start)
+			if !late {
+				timer := clock.NewTimer(t.Sub(clock.Now()))
+				select {
+				case <-timer.C():
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				}
+			}
+			select {
+			case ch <- Crossing{At: t, Index: i, Late: late}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// RangeBoundaries 返回各个时间范围的开始和结束时间, 用于监听范围的开始和结束. 第 i 个时间范围的开始时间位于下标 2i, 结束时间位于下标 2i+1.
+func RangeBoundaries(ranges []*TimeRange) []time.Time {
+	boundaries := make([]time.Time, 0, 2*len(ranges))
+	for _, tr := range ranges {
+		boundaries = append(boundaries, tr.start, tr.end)
+	}
+	return boundaries
+}
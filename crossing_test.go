@@ -0,0 +1,88 @@
+package timex
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// receiveCrossing 等待通道中的下一个通知, 超时或通道关闭时返回 false
+func receiveCrossing(t *testing.T, ch <-chan Crossing) (Crossing, bool) {
+	t.Helper()
+	select {
+	case c, ok := <-ch:
+		return c, ok
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a crossing")
+		return Crossing{}, false
+	}
+}
+
+func TestCrossings(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	instants := []time.Time{t0.Add(2 * time.Hour), t0.Add(-time.Hour), t0.Add(-3 * time.Hour), t0.Add(time.Hour), t0.Add(time.Hour)}
+	// 下标 2 不晚于 since, 视为已经处理过
+	ch := Crossings(context.Background(), c, instants, t0.Add(-2*time.Hour))
+
+	if got, _ := receiveCrossing(t, ch); got != (Crossing{At: instants[1], Index: 1, Late: true}) {
+		t.Fatalf("first crossing = %+v, want late index 1", got)
+	}
+
+	c.BlockUntil(1)
+	select {
+	case got := <-ch:
+		t.Fatalf("crossing %+v sent before its instant", got)
+	default:
+	}
+	c.Advance(time.Hour)
+	// 相同的时间点按下标顺序发送
+	for _, want := range []Crossing{{At: instants[3], Index: 3}, {At: instants[4], Index: 4}} {
+		if got, _ := receiveCrossing(t, ch); got != want {
+			t.Fatalf("crossing = %+v, want %+v", got, want)
+		}
+	}
+
+	c.BlockUntil(1)
+	c.Advance(time.Hour)
+	if got, _ := receiveCrossing(t, ch); got != (Crossing{At: instants[0], Index: 0}) {
+		t.Fatalf("crossing = %+v, want index 0", got)
+	}
+	if got, ok := receiveCrossing(t, ch); ok {
+		t.Errorf("got %+v after all instants, want closed channel", got)
+	}
+}
+
+func TestCrossingsCancel(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewFakeClock(t0)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Crossings(ctx, c, []time.Time{t0.Add(time.Hour)}, time.Time{})
+
+	c.BlockUntil(1)
+	cancel()
+	if got, ok := receiveCrossing(t, ch); ok {
+		t.Errorf("got %+v after cancel, want closed channel", got)
+	}
+	if n := c.PendingTimers(); n != 0 {
+		t.Errorf("PendingTimers = %d after cancel, want 0", n)
+	}
+}
+
+func TestRangeBoundaries(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ranges := []*TimeRange{
+		MustNewTimeRange(t0, t0.Add(time.Hour), true, false),
+		MustNewTimeRange(t0.Add(30*time.Minute), t0.Add(2*time.Hour), true, true),
+	}
+	got := RangeBoundaries(ranges)
+	want := []time.Time{t0, t0.Add(time.Hour), t0.Add(30 * time.Minute), t0.Add(2 * time.Hour)}
+	if len(got) != len(want) {
+		t.Fatalf("RangeBoundaries = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("RangeBoundaries[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
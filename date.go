@@ -0,0 +1,169 @@
+package timex
+
+import (
+	"cmp"
+	"errors"
+	"time"
+)
+
+// ErrInvalidDate 表示无效的日期
+var ErrInvalidDate = errors.New("invalid date")
+
+const (
+	dateLayout    = "2006-01-02"
+	secondsPerDay = 24 * 60 * 60
+)
+
+// Date 表示一个不带时刻和时区的日历日期, 可以作为 map 的键使用.
+// 文本及 JSON 编码格式为 "YYYY-MM-DD".
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate 创建Date, 超出范围的月份和日期会像 time.Date 一样被规范化, 如 2024-02-30 会变为 2024-03-01
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf 返回时间在其自身时区中的日期
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DateOfByTz 返回时间在指定时区中的日期
+func DateOfByTz(t time.Time, loc *time.Location) Date {
+	return DateOf(t.In(loc))
+}
+
+// ParseDate 解析 "YYYY-MM-DD" 格式的日期
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// IsZero 判断是否为零值
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid 判断是否为一个实际存在的日期, 如 2023-02-29 是无效的
+func (d Date) IsValid() bool {
+	return d.Month >= time.January && d.Month <= time.December && d.Day >= 1 && d.Day <= DaysInMonth(d.Year, d.Month)
+}
+
+// In 返回该日期在指定时区中的零点
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Weekday 返回星期
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// YearDay 返回该日期是一年中的第几天, 从 1 开始
+func (d Date) YearDay() int {
+	return d.In(time.UTC).YearDay()
+}
+
+// AddDays 返回 n 天之后的日期, n 可以为负数
+func (d Date) AddDays(n int) Date {
+	return NewDate(d.Year, d.Month, d.Day+n)
+}
+
+// AddMonths 返回 n 个月之后的日期, n 可以为负数.
+// 与 time.AddDate 不同, 目标月份没有对应日期时会取该月最后一天, 如 01-31 加一个月得到 02-28 或 02-29.
+func (d Date) AddMonths(n int) Date {
+	year, month := addMonths(d.Year, d.Month, n)
+	return Date{Year: year, Month: month, Day: min(d.Day, DaysInMonth(year, month))}
+}
+
+// AddYears 返回 n 年之后的日期, n 可以为负数, 02-29 在非闰年会变为 02-28
+func (d Date) AddYears(n int) Date {
+	return d.AddMonths(n * 12)
+}
+
+// DaysSince 返回从 other 到 d 经过的天数, d 早于 other 时为负数
+func (d Date) DaysSince(other Date) int {
+	// 不经过 time.Duration, 以免相差约 292 年以上时溢出
+	return int((d.In(time.UTC).Unix() - other.In(time.UTC).Unix()) / secondsPerDay)
+}
+
+// Compare 比较两个日期, d 早于 other 时返回 -1, 晚于时返回 1, 相同时返回 0
+func (d Date) Compare(other Date) int {
+	switch {
+	case d.Year != other.Year:
+		return cmp.Compare(d.Year, other.Year)
+	case d.Month != other.Month:
+		return cmp.Compare(int(d.Month), int(other.Month))
+	default:
+		return cmp.Compare(d.Day, other.Day)
+	}
+}
+
+// Before 判断 d 是否早于 other
+func (d Date) Before(other Date) bool {
+	return d.Compare(other) < 0
+}
+
+// After 判断 d 是否晚于 other
+func (d Date) After(other Date) bool {
+	return d.Compare(other) > 0
+}
+
+// String 返回 "YYYY-MM-DD" 格式的文本
+func (d Date) String() string {
+	return d.In(time.UTC).Format(dateLayout)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.IsValid() {
+		return nil, ErrInvalidDate
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (d *Date) UnmarshalText(text []byte) error {
+	v, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// addMonths 返回 year 年 month 月之后 n 个月的年份和月份
+func addMonths(year int, month time.Month, n int) (int, time.Month) {
+	m := year*12 + int(month) - 1 + n
+	y := m / 12
+	if m%12 < 0 {
+		y--
+	}
+	return y, time.Month(m-y*12) + 1
+}
+
+// IsLeapYear 判断是否为公历闰年, 即能被 4 整除但不能被 100 整除, 或者能被 400 整除的年份
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// DaysInMonth 返回指定月份的天数, 闰年的二月为 29 天
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// DaysInYear 返回指定年份的天数, 闰年为 366 天, 否则为 365 天
+func DaysInYear(year int) int {
+	if IsLeapYear(year) {
+		return 366
+	}
+	return 365
+}
@@ -0,0 +1,81 @@
+package timex
+
+import (
+	"errors"
+	"iter"
+	"time"
+)
+
+// ErrInvalidDateRange 表示无效的日期范围错误
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+// DateRange 表示一个包含起止日期在内的日期范围, 适合预订, 计价等以天为粒度的场景
+type DateRange struct {
+	start Date
+	end   Date
+}
+
+// MustNewDateRange 创建DateRange, 如果参数无效则 panic
+func MustNewDateRange(start, end Date) *DateRange {
+	dr, err := NewDateRange(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return dr
+}
+
+// NewDateRange 创建DateRange, start 和 end 都包含在范围内
+func NewDateRange(start, end Date) (*DateRange, error) {
+	if !start.IsValid() || !end.IsValid() || start.After(end) {
+		return nil, ErrInvalidDateRange
+	}
+	return &DateRange{
+		start: start,
+		end:   end,
+	}, nil
+}
+
+// Start 返回开始日期
+func (dr *DateRange) Start() Date {
+	return dr.start
+}
+
+// End 返回结束日期
+func (dr *DateRange) End() Date {
+	return dr.end
+}
+
+// Days 返回范围内的天数, 包含起止日期
+func (dr *DateRange) Days() int {
+	return dr.end.DaysSince(dr.start) + 1
+}
+
+// Contains 判断日期是否在范围内
+func (dr *DateRange) Contains(d Date) bool {
+	return !d.Before(dr.start) && !d.After(dr.end)
+}
+
+// Overlaps 判断两个日期范围是否有重叠的日期
+func (dr *DateRange) Overlaps(other *DateRange) bool {
+	return !dr.start.After(other.end) && !other.start.After(dr.end)
+}
+
+// IterDays 依次迭代范围内的每一天
+func (dr *DateRange) IterDays() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := dr.start; !d.After(dr.end); d = d.AddDays(1) {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// ToTimeRange 转换为指定时区中从开始日期零点 (包含) 到结束日期次日零点 (不包含) 的 TimeRange
+func (dr *DateRange) ToTimeRange(loc *time.Location) *TimeRange {
+	return &TimeRange{
+		start:          startOfLocalDay(dr.start, loc),
+		end:            startOfLocalDay(dr.end.AddDays(1), loc),
+		startInclusive: true,
+	}
+}
@@ -0,0 +1,76 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateDaysSince(t *testing.T) {
+	cases := []struct {
+		d, other Date
+		want     int
+	}{
+		{Date{2024, 3, 1}, Date{2024, 2, 28}, 2},
+		{Date{2023, 3, 1}, Date{2023, 2, 28}, 1},
+		{Date{2024, 1, 1}, Date{2024, 1, 1}, 0},
+		{Date{2024, 1, 1}, Date{2024, 12, 31}, -365},
+		{Date{2300, 1, 1}, Date{1600, 1, 1}, 255670},
+		{Date{1600, 1, 1}, Date{2300, 1, 1}, -255670},
+		{Date{1, 1, 1}, Date{-1, 1, 1}, 731},
+	}
+	for _, c := range cases {
+		if got := c.d.DaysSince(c.other); got != c.want {
+			t.Errorf("%v.DaysSince(%v) = %d, want %d", c.d, c.other, got, c.want)
+		}
+	}
+}
+
+func TestDateRangeDaysLongSpan(t *testing.T) {
+	dr := MustNewDateRange(Date{1600, 1, 1}, Date{2299, 12, 31})
+	if got := dr.Days(); got != 255670 {
+		t.Errorf("Days() = %d, want 255670", got)
+	}
+}
+
+func TestLeapYears(t *testing.T) {
+	cases := []struct {
+		year     int
+		leap     bool
+		february int
+		days     int
+	}{
+		{1900, false, 28, 365},
+		{2000, true, 29, 366},
+		{2023, false, 28, 365},
+		{2024, true, 29, 366},
+		{2100, false, 28, 365},
+		{0, true, 29, 366},
+		{-1, false, 28, 365},
+		{-4, true, 29, 366},
+		{-100, false, 28, 365},
+		{-400, true, 29, 366},
+	}
+	for _, c := range cases {
+		if got := IsLeapYear(c.year); got != c.leap {
+			t.Errorf("IsLeapYear(%d) = %v, want %v", c.year, got, c.leap)
+		}
+		if got := DaysInMonth(c.year, time.February); got != c.february {
+			t.Errorf("DaysInMonth(%d, February) = %d, want %d", c.year, got, c.february)
+		}
+		if got := DaysInYear(c.year); got != c.days {
+			t.Errorf("DaysInYear(%d) = %d, want %d", c.year, got, c.days)
+		}
+		if got := (Date{c.year, time.February, 29}).IsValid(); got != c.leap {
+			t.Errorf("%d-02-29 IsValid() = %v, want %v", c.year, got, c.leap)
+		}
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	want := []int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	for m := time.January; m <= time.December; m++ {
+		if got := DaysInMonth(2023, m); got != want[m-1] {
+			t.Errorf("DaysInMonth(2023, %v) = %d, want %d", m, got, want[m-1])
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package timex
+
+import "time"
+
+// DeadlineRules 描述由事件时间推算截止时间时适用的规则, 如不同司法辖区对期限届满日的规定
+type DeadlineRules struct {
+	// BusinessDays 为 true 时 Period 中的天数按工作日计算, 需要提供工作日历
+	BusinessDays bool
+	// Roll 表示截止日不是工作日时的调整惯例, 如 "期限届满日为节假日的, 顺延至节假日后的第一个工作日" 对应 RollFollowing, 需要提供工作日历
+	Roll RollConvention
+	// EndOfDay 为 true 时截止时间为截止日当天的最后一刻, 否则与事件发生的时刻相同
+	EndOfDay bool
+}
+
+// DeadlineFrom 返回从事件时间 event 开始, 经过 period 后按照 rules 调整得到的截止时间.
+// 日期按照工作日历所在时区计算, cal 为 nil 时使用 event 自身的时区, 且 rules 中依赖工作日历的规则不生效.
+// 期间的年和月按日历推算, 目标月份没有对应日期时取该月最后一天, 随后再加上天数; 时, 分, 秒部分只在 EndOfDay 为 false 时累加到结果上.
+func DeadlineFrom(event time.Time, period Period, rules DeadlineRules, cal *BusinessCalendar) time.Time {
+	loc := event.Location()
+	if cal != nil {
+		loc = cal.loc
+	}
+
+	d := DateOfByTz(event, loc)
+	if cal != nil && rules.BusinessDays {
+		d = cal.addBusinessDays(addPeriodToDate(d, Period{Years: period.Years, Months: period.Months}, OverflowClamp), period.Days)
+	} else {
+		d = addPeriodToDate(d, period, OverflowClamp)
+	}
+	if cal != nil {
+		d = cal.Roll(d, rules.Roll)
+	}
+
+	if rules.EndOfDay {
+		return startOfLocalDay(d.AddDays(1), loc).Add(-time.Nanosecond)
+	}
+	return TimeOfDayOf(event.In(loc)).On(d, loc).Add(period.timeDuration())
+}
@@ -0,0 +1,50 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineFrom(t *testing.T) {
+	cal := MustNewBusinessCalendar(time.UTC, NewTimeOfDay(9, 0, 0), NewTimeOfDay(17, 0, 0))
+	cal.AddHolidays(Date{2024, 5, 1})
+	at := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		name   string
+		event  time.Time
+		period Period
+		rules  DeadlineRules
+		cal    *BusinessCalendar
+		want   time.Time
+	}{
+		{"calendar days rolled past holiday", at(2024, 4, 26, 10), Period{Days: 5}, DeadlineRules{Roll: RollFollowing}, cal, at(2024, 5, 2, 10)},
+		{"calendar days without roll", at(2024, 4, 26, 10), Period{Days: 5}, DeadlineRules{}, cal, at(2024, 5, 1, 10)},
+		{"business days skip weekend and holiday", at(2024, 4, 26, 10), Period{Days: 3}, DeadlineRules{BusinessDays: true}, cal, at(2024, 5, 2, 10)},
+		{"month end clamp", at(2024, 1, 31, 10), Period{Months: 1}, DeadlineRules{}, nil, at(2024, 2, 29, 10)},
+		{"modified following stays in month", at(2024, 7, 31, 10), Period{Months: 1}, DeadlineRules{Roll: RollModifiedFollowing}, cal, at(2024, 8, 30, 10)},
+		{"preceding", at(2024, 4, 24, 10), Period{Days: 3}, DeadlineRules{Roll: RollPreceding}, cal, at(2024, 4, 26, 10)},
+		{"end of day", at(2024, 4, 26, 10), Period{Days: 3}, DeadlineRules{Roll: RollFollowing, EndOfDay: true}, cal, at(2024, 4, 30, 0).Add(-time.Nanosecond)},
+		{"time part added", at(2024, 4, 26, 10), Period{Days: 1, Hours: 2}, DeadlineRules{}, nil, at(2024, 4, 27, 12)},
+		{"business days ignored without calendar", at(2024, 4, 26, 10), Period{Days: 3}, DeadlineRules{BusinessDays: true, Roll: RollFollowing}, nil, at(2024, 4, 29, 10)},
+	}
+	for _, c := range cases {
+		if got := DeadlineFrom(c.event, c.period, c.rules, c.cal); !got.Equal(c.want) {
+			t.Errorf("%s: DeadlineFrom = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestDeadlineFromCalendarTimezone(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	cal := MustNewBusinessCalendar(shanghai, NewTimeOfDay(9, 0, 0), NewTimeOfDay(18, 0, 0))
+	// UTC 周五 20:00 在上海已经是周六
+	event := time.Date(2024, 4, 26, 20, 0, 0, 0, time.UTC)
+	got := DeadlineFrom(event, Period{Days: 1}, DeadlineRules{BusinessDays: true, EndOfDay: true}, cal)
+	if want := time.Date(2024, 4, 30, 0, 0, 0, 0, shanghai).Add(-time.Nanosecond); !got.Equal(want) {
+		t.Errorf("DeadlineFrom = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package timex
+
+import "time"
+
+// Urgency 表示截止时间的紧迫程度
+type Urgency int
+
+const (
+	// UrgencyLow 距离截止时间超过一周
+	UrgencyLow Urgency = iota
+	// UrgencyMedium 距离截止时间不超过一周
+	UrgencyMedium
+	// UrgencyHigh 距离截止时间不超过一天
+	UrgencyHigh
+	// UrgencyOverdue 已经超过截止时间
+	UrgencyOverdue
+)
+
+// String 返回紧迫程度的名称
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "low"
+	case UrgencyMedium:
+		return "medium"
+	case UrgencyHigh:
+		return "high"
+	case UrgencyOverdue:
+		return "overdue"
+	default:
+		return "unknown"
+	}
+}
+
+// DescribeDeadline 描述截止时间 t 相对于 now 的剩余时间和紧迫程度, 如 "due in 3 days" / "3天后到期", "overdue by 2 hours" / "已逾期2小时".
+// 时长的描述规则与 RelativeTime 相同, 相差不足一分钟时为 "due now" / "即将到期", 紧迫程度为 UrgencyHigh.
+func DescribeDeadline(t, now time.Time, lang Locale) (string, Urgency) {
+	d := t.Sub(now)
+	zh := lang.isChinese()
+	switch {
+	case d > -time.Minute && d < time.Minute:
+		if zh {
+			return "即将到期", UrgencyHigh
+		}
+		return "due now", UrgencyHigh
+	case d < 0:
+		if zh {
+			return "已逾期" + relativeAmount(-d, lang), UrgencyOverdue
+		}
+		return "overdue by " + relativeAmount(-d, lang), UrgencyOverdue
+	}
+
+	urgency := UrgencyLow
+	switch {
+	case d <= 24*time.Hour:
+		urgency = UrgencyHigh
+	case d <= 7*24*time.Hour:
+		urgency = UrgencyMedium
+	}
+	if zh {
+		return relativeAmount(d, lang) + "后到期", urgency
+	}
+	return "due in " + relativeAmount(d, lang), urgency
+}
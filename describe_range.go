@@ -0,0 +1,101 @@
+package timex
+
+import (
+	"strconv"
+	"time"
+)
+
+var chineseWeekdays = []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"}
+
+// DescribeRange 返回时间范围在 loc 中的简洁描述, 相同的年, 月, 日部分只输出一次, 如:
+//   - 整年: "2024" / "2024年"
+//   - 整月: "Jan 2024" / "2024年1月"
+//   - 整天: "May 1, 2024", "May 1–3, 2024", "May 30 – Jun 2, 2024" / "2024年5月1日", "2024年5月1日至3日"
+//   - 同一天内: "Wed, May 1, 2024 09:00–17:00" / "2024年5月1日 周三 09:00–17:00"
+//   - 其他: "May 1 09:00 – May 3 17:00, 2024" / "2024年5月1日 09:00 至 5月3日 17:00"
+//
+// 开始时间和结束时间 (不包含时的结束时间, 或包含时结束时间之后的 1 纳秒) 都在一天的开始时视为整天. 时刻只有在秒不为 0 时才输出秒.
+func DescribeRange(tr *TimeRange, loc *time.Location, lang Locale) string {
+	start, end := tr.start.In(loc), tr.end.In(loc)
+	exclusiveEnd := end
+	if tr.endInclusive {
+		exclusiveEnd = end.Add(time.Nanosecond)
+	}
+	zh := lang.isChinese()
+
+	if isBoundary(start, UnitDay, loc) && isBoundary(exclusiveEnd, UnitDay, loc) && exclusiveEnd.After(start) {
+		first, last := DateOf(start), DateOfByTz(exclusiveEnd, loc).AddDays(-1)
+		return describeDays(first, last, zh)
+	}
+
+	layout := "15:04"
+	if start.Second() != 0 || end.Second() != 0 {
+		layout = "15:04:05"
+	}
+	ds, de := DateOf(start), DateOf(end)
+	switch {
+	case ds == de && zh:
+		return describeDate(ds, true, true) + " " + chineseWeekdays[ds.Weekday()] + " " + start.Format(layout) + "–" + end.Format(layout)
+	case ds == de:
+		return start.Format("Mon, ") + describeDate(ds, true, false) + " " + start.Format(layout) + "–" + end.Format(layout)
+	case ds.Year == de.Year && zh:
+		return describeDate(ds, true, true) + " " + start.Format(layout) + " 至 " + describeDate(de, false, true) + " " + end.Format(layout)
+	case ds.Year == de.Year:
+		return describeDate(ds, false, false) + " " + start.Format(layout) + " – " + describeDate(de, false, false) + " " + end.Format(layout) + ", " + strconv.Itoa(ds.Year)
+	case zh:
+		return describeDate(ds, true, true) + " " + start.Format(layout) + " 至 " + describeDate(de, true, true) + " " + end.Format(layout)
+	default:
+		return describeDate(ds, true, false) + " " + start.Format(layout) + " – " + describeDate(de, true, false) + " " + end.Format(layout)
+	}
+}
+
+// describeDays 描述 [first, last] 这些整天
+func describeDays(first, last Date, zh bool) string {
+	year := strconv.Itoa(first.Year)
+	wholeMonth := first.Day == 1 && last.Day == DaysInMonth(last.Year, last.Month)
+	switch {
+	case wholeMonth && first.Year == last.Year && first.Month == time.January && last.Month == time.December:
+		if zh {
+			return year + "年"
+		}
+		return year
+	case wholeMonth && first.Year == last.Year && first.Month == last.Month:
+		if zh {
+			return year + "年" + strconv.Itoa(int(first.Month)) + "月"
+		}
+		return first.Month.String()[:3] + " " + year
+	case first == last:
+		return describeDate(first, true, zh)
+	case first.Year == last.Year && first.Month == last.Month:
+		if zh {
+			return describeDate(first, true, true) + "至" + strconv.Itoa(last.Day) + "日"
+		}
+		return first.Month.String()[:3] + " " + strconv.Itoa(first.Day) + "–" + strconv.Itoa(last.Day) + ", " + year
+	case first.Year == last.Year:
+		if zh {
+			return describeDate(first, true, true) + "至" + describeDate(last, false, true)
+		}
+		return describeDate(first, false, false) + " – " + describeDate(last, false, false) + ", " + year
+	default:
+		if zh {
+			return describeDate(first, true, true) + "至" + describeDate(last, true, true)
+		}
+		return describeDate(first, true, false) + " – " + describeDate(last, true, false)
+	}
+}
+
+// describeDate 返回 "May 1, 2024" / "2024年5月1日" 形式的日期, withYear 为 false 时省略年份
+func describeDate(d Date, withYear, zh bool) string {
+	if zh {
+		s := strconv.Itoa(int(d.Month)) + "月" + strconv.Itoa(d.Day) + "日"
+		if withYear {
+			s = strconv.Itoa(d.Year) + "年" + s
+		}
+		return s
+	}
+	s := d.Month.String()[:3] + " " + strconv.Itoa(d.Day)
+	if withYear {
+		s += ", " + strconv.Itoa(d.Year)
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribeRange(t *testing.T) {
+	at := func(y int, mo time.Month, d, h, m, s int) time.Time { return time.Date(y, mo, d, h, m, s, 0, time.UTC) }
+	cases := []struct {
+		tr     *TimeRange
+		en, zh string
+	}{
+		{MustNewTimeRange(at(2024, 1, 1, 0, 0, 0), at(2025, 1, 1, 0, 0, 0), true, false), "2024", "2024年"},
+		// 包含结束时间时以结束时间之后的 1 纳秒判断整天
+		{MustNewTimeRange(at(2024, 1, 1, 0, 0, 0), at(2025, 1, 1, 0, 0, 0).Add(-time.Nanosecond), true, true), "2024", "2024年"},
+		{MustNewTimeRange(at(2024, 2, 1, 0, 0, 0), at(2024, 3, 1, 0, 0, 0), true, false), "Feb 2024", "2024年2月"},
+		{MustNewTimeRange(at(2024, 1, 1, 0, 0, 0), at(2024, 4, 1, 0, 0, 0), true, false), "Jan 1 – Mar 31, 2024", "2024年1月1日至3月31日"},
+		{MustNewTimeRange(at(2024, 5, 1, 0, 0, 0), at(2024, 5, 2, 0, 0, 0), true, false), "May 1, 2024", "2024年5月1日"},
+		{MustNewTimeRange(at(2024, 5, 1, 0, 0, 0), at(2024, 5, 4, 0, 0, 0), true, false), "May 1–3, 2024", "2024年5月1日至3日"},
+		{MustNewTimeRange(at(2024, 5, 30, 0, 0, 0), at(2024, 6, 3, 0, 0, 0), true, false), "May 30 – Jun 2, 2024", "2024年5月30日至6月2日"},
+		{MustNewTimeRange(at(2023, 12, 30, 0, 0, 0), at(2024, 1, 3, 0, 0, 0), true, false), "Dec 30, 2023 – Jan 2, 2024", "2023年12月30日至2024年1月2日"},
+		{MustNewTimeRange(at(2024, 5, 1, 9, 0, 0), at(2024, 5, 1, 17, 0, 0), true, false), "Wed, May 1, 2024 09:00–17:00", "2024年5月1日 周三 09:00–17:00"},
+		// 秒不为 0 时两端都输出秒
+		{MustNewTimeRange(at(2024, 5, 1, 9, 0, 30), at(2024, 5, 1, 17, 0, 0), true, false), "Wed, May 1, 2024 09:00:30–17:00:00", "2024年5月1日 周三 09:00:30–17:00:00"},
+		{MustNewTimeRange(at(2024, 5, 1, 9, 0, 0), at(2024, 5, 3, 17, 0, 0), true, false), "May 1 09:00 – May 3 17:00, 2024", "2024年5月1日 09:00 至 5月3日 17:00"},
+		{MustNewTimeRange(at(2023, 12, 31, 22, 0, 0), at(2024, 1, 1, 2, 0, 0), true, false), "Dec 31, 2023 22:00 – Jan 1, 2024 02:00", "2023年12月31日 22:00 至 2024年1月1日 02:00"},
+	}
+	for _, c := range cases {
+		if got := DescribeRange(c.tr, time.UTC, LocaleEnglish); got != c.en {
+			t.Errorf("DescribeRange(%v) = %q, want %q", c.tr, got, c.en)
+		}
+		if got := DescribeRange(c.tr, time.UTC, LocaleChinese); got != c.zh {
+			t.Errorf("DescribeRange(%v, zh-CN) = %q, want %q", c.tr, got, c.zh)
+		}
+	}
+}
+
+func TestDescribeRangeLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 夏令时开始的那天只有 23 小时, 在 ny 中仍是整天
+	tr := MustNewTimeRange(time.Date(2024, 3, 10, 0, 0, 0, 0, ny), time.Date(2024, 3, 11, 0, 0, 0, 0, ny), true, false)
+	if got := DescribeRange(tr, ny, LocaleEnglish); got != "Mar 10, 2024" {
+		t.Errorf("DescribeRange in New York = %q", got)
+	}
+	if got := DescribeRange(tr, time.UTC, LocaleEnglish); got != "Mar 10 05:00 – Mar 11 04:00, 2024" {
+		t.Errorf("DescribeRange in UTC = %q", got)
+	}
+}
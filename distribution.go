@@ -0,0 +1,63 @@
+package timex
+
+import "time"
+
+// DistributionWeights 表示 WeightedDistributionReport 中不同类型日期的时长权重
+type DistributionWeights struct {
+	Weekday float64 // 工作日的权重
+	Weekend float64 // 非节假日的周末的权重
+	Holiday float64 // 节假日的权重
+}
+
+// DistributionReport 汇总 within 内每个标签分配到的总时长, 用于检查值班等安排是否公平. 超出 within 的部分不计入.
+func DistributionReport(assignments []LabeledRange[string], within *TimeRange) map[string]time.Duration {
+	report := map[string]time.Duration{}
+	for _, a := range assignments {
+		if s, e, ok := overlapBounds(a.Range, within); ok {
+			report[a.Label] += e.Sub(s)
+		}
+	}
+	return report
+}
+
+// WeightedDistributionReport 与 DistributionReport 相同, 但每段时长按其所在日期的类型加权, 日期按照 cal 的时区划分.
+// 例如 Weekend 为 1.5, Holiday 为 2 时, 节假日值班一小时计为两小时.
+func WeightedDistributionReport(assignments []LabeledRange[string], within *TimeRange, cal *BusinessCalendar, weights DistributionWeights) map[string]time.Duration {
+	report := map[string]time.Duration{}
+	for _, a := range assignments {
+		s, e, ok := overlapBounds(a.Range, within)
+		if !ok {
+			continue
+		}
+		var total float64
+		for d := DateOfByTz(s, cal.loc); s.Before(e); d = d.AddDays(1) {
+			next := startOfLocalDay(d.AddDays(1), cal.loc)
+			if next.After(e) {
+				next = e
+			}
+			w := weights.Weekday
+			switch {
+			case cal.isHoliday(d):
+				w = weights.Holiday
+			case !cal.IsBusinessDay(d):
+				w = weights.Weekend
+			}
+			total += w * float64(next.Sub(s))
+			s = next
+		}
+		report[a.Label] += time.Duration(total)
+	}
+	return report
+}
+
+// overlapBounds 返回两个时间范围重叠部分的开始和结束时间, 不考虑端点的开闭性, 没有重叠时返回 false
+func overlapBounds(a, b *TimeRange) (start, end time.Time, ok bool) {
+	start, end = a.start, a.end
+	if b.start.After(start) {
+		start = b.start
+	}
+	if b.end.Before(end) {
+		end = b.end
+	}
+	return start, end, start.Before(end)
+}
@@ -0,0 +1,165 @@
+package timex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrDuplicateJob 表示定时任务名称重复
+var ErrDuplicateJob = errors.New("duplicate job")
+
+// TimerStore 持久化定时任务的触发进度, 使 DurableRunner 重启后可以补上期间错过的触发
+type TimerStore interface {
+	// Save 记录任务 name 即将处理 pending 时刻的触发
+	Save(name string, pending time.Time) error
+	// Load 返回任务 name 最后一次确认处理完毕的触发时刻 acked, 以及通过 Save 记录但尚未确认的触发时刻 pending, 没有记录的返回零值
+	Load(name string) (acked, pending time.Time, err error)
+	// Ack 确认任务 name 在 at 时刻的触发已经处理完毕
+	Ack(name string, at time.Time) error
+}
+
+// MemoryTimerStore 是保存在内存中的 TimerStore 实现, 适合测试或不需要跨进程持久化的场景
+type MemoryTimerStore struct {
+	mu      sync.Mutex
+	pending map[string]time.Time
+	acked   map[string]time.Time
+}
+
+// NewMemoryTimerStore 创建MemoryTimerStore
+func NewMemoryTimerStore() *MemoryTimerStore {
+	return &MemoryTimerStore{pending: map[string]time.Time{}, acked: map[string]time.Time{}}
+}
+
+// Save 实现 TimerStore
+func (s *MemoryTimerStore) Save(name string, pending time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending[name] = pending
+	return nil
+}
+
+// Load 实现 TimerStore
+func (s *MemoryTimerStore) Load(name string) (acked, pending time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.acked[name], s.pending[name], nil
+}
+
+// Ack 实现 TimerStore
+func (s *MemoryTimerStore) Ack(name string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acked[name] = at
+	if p, ok := s.pending[name]; ok && !p.After(at) {
+		delete(s.pending, name)
+	}
+	return nil
+}
+
+// Pending 返回任务 name 已经开始处理但尚未确认的触发时刻
+func (s *MemoryTimerStore) Pending(name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, ok := s.pending[name]
+	return t, ok
+}
+
+// DurableRunner 按照 Schedule 执行定时任务, 并通过 TimerStore 记录进度.
+// 启动时从 TimerStore 读取每个任务最后确认的触发时刻, 之后错过的所有触发都会按时间顺序立即补上; 没有记录的新任务从启动时刻开始计算, 不补发.
+// 上次运行在 Save 之后, Ack 之前中断的触发 (包括新任务的第一次触发) 会在启动时首先重新执行.
+type DurableRunner struct {
+	clock Clock
+	store TimerStore
+	jobs  []*durableJob
+}
+
+type durableJob struct {
+	name     string
+	schedule Schedule
+	handler  func(ctx context.Context, at time.Time) error
+	next     time.Time
+}
+
+// NewDurableRunner 创建DurableRunner
+func NewDurableRunner(clock Clock, store TimerStore) *DurableRunner {
+	return &DurableRunner{clock: clock, store: store}
+}
+
+// Add 添加定时任务, handler 的 at 参数是本次触发的计划时刻. 需要在 Run 之前调用, 名称重复时返回 ErrDuplicateJob.
+func (r *DurableRunner) Add(name string, s Schedule, handler func(ctx context.Context, at time.Time) error) error {
+	for _, job := range r.jobs {
+		if job.name == name {
+			return ErrDuplicateJob
+		}
+	}
+	r.jobs = append(r.jobs, &durableJob{name: name, schedule: s, handler: handler})
+	return nil
+}
+
+// Run 依次执行各个任务的触发, 同一时刻只执行一个 handler, 多个任务的触发按计划时刻的先后顺序执行.
+// 每次触发前调用 TimerStore.Save, handler 成功返回后调用 TimerStore.Ack; handler 或 TimerStore 返回错误时 Run 停止并返回该错误, 该触发会在下次启动时重新执行.
+// 所有任务都没有更多触发时返回 nil, ctx 被取消时返回 ctx.Err().
+func (r *DurableRunner) Run(ctx context.Context) error {
+	now := r.clock.Now()
+	var jobs []*durableJob
+	for _, job := range r.jobs {
+		last, pending, err := r.store.Load(job.name)
+		if err != nil {
+			return err
+		}
+		if !pending.IsZero() && (last.IsZero() || pending.After(last)) {
+			job.next = pending
+			jobs = append(jobs, job)
+			continue
+		}
+		if last.IsZero() {
+			last = now
+		}
+		if next, ok := job.schedule.Next(last); ok {
+			job.next = next
+			jobs = append(jobs, job)
+		}
+	}
+
+	for len(jobs) > 0 {
+		i := 0
+		for j, job := range jobs {
+			if job.next.Before(jobs[i].next) {
+				i = j
+			}
+		}
+		job := jobs[i]
+
+		if d := job.next.Sub(r.clock.Now()); d > 0 {
+			timer := r.clock.NewTimer(d)
+			select {
+			case <-timer.C():
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := r.store.Save(job.name, job.next); err != nil {
+			return err
+		}
+		if err := job.handler(ctx, job.next); err != nil {
+			return fmt.Errorf("timex: job %q at %s: %w", job.name, job.next.Format(time.RFC3339), err)
+		}
+		if err := r.store.Ack(job.name, job.next); err != nil {
+			return err
+		}
+
+		if next, ok := job.schedule.Next(job.next); ok {
+			job.next = next
+		} else {
+			jobs = append(jobs[:i], jobs[i+1:]...)
+		}
+	}
+	return nil
+}
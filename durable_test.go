@@ -0,0 +1,79 @@
+package timex
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDurableRunnerReplaysPending(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name  string
+		acked time.Time
+	}{
+		{"after ack", t0},
+		{"first occurrence", time.Time{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store := NewMemoryTimerStore()
+			if !c.acked.IsZero() {
+				_ = store.Ack("job", c.acked)
+			}
+			// 上次运行在 Save 之后, Ack 之前中断
+			_ = store.Save("job", t0.Add(time.Hour))
+
+			clock := NewFakeClock(t0.Add(90 * time.Minute))
+			runner := NewDurableRunner(clock, store)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var got []time.Time
+			_ = runner.Add("job", Every(t0, time.Hour), func(ctx context.Context, at time.Time) error {
+				got = append(got, at)
+				cancel()
+				return nil
+			})
+
+			if err := runner.Run(ctx); !errors.Is(err, context.Canceled) {
+				t.Fatalf("Run = %v, want context.Canceled", err)
+			}
+			if want := []time.Time{t0.Add(time.Hour)}; !slices.EqualFunc(got, want, time.Time.Equal) {
+				t.Fatalf("handler calls = %v, want %v", got, want)
+			}
+			if _, ok := store.Pending("job"); ok {
+				t.Error("pending occurrence should be acked after replay")
+			}
+			if acked, _, _ := store.Load("job"); !acked.Equal(t0.Add(time.Hour)) {
+				t.Errorf("acked = %v, want %v", acked, t0.Add(time.Hour))
+			}
+		})
+	}
+}
+
+func TestDurableRunnerCatchesUp(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryTimerStore()
+	_ = store.Ack("job", t0)
+	clock := NewFakeClock(t0.Add(150 * time.Minute))
+	runner := NewDurableRunner(clock, store)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []time.Time
+	_ = runner.Add("job", Every(t0, time.Hour), func(ctx context.Context, at time.Time) error {
+		if got = append(got, at); len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err := runner.Add("job", Every(t0, time.Hour), nil); !errors.Is(err, ErrDuplicateJob) {
+		t.Errorf("Add duplicate = %v, want ErrDuplicateJob", err)
+	}
+
+	_ = runner.Run(ctx)
+	if want := []time.Time{t0.Add(time.Hour), t0.Add(2 * time.Hour)}; !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Fatalf("handler calls = %v, want %v", got, want)
+	}
+}
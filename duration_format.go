@@ -0,0 +1,89 @@
+package timex
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFormat 描述 FormatDuration 的输出格式
+type DurationFormat struct {
+	MaxUnits int           // 最多输出的单位个数, 从第一个非零的单位开始计算, 不大于 0 时按 2 处理
+	MinUnit  time.Duration // 最小的输出单位, 只能是 time.Millisecond, time.Second, time.Minute, time.Hour 或 24 * time.Hour, 为 0 时按 time.Second 处理
+	Round    bool          // 为 true 时对不输出的部分四舍五入, 否则直接舍去
+	Long     bool          // 为 true 时输出 "3 days 4 hours" 的形式, 否则输出 "3d 4h" 的形式
+}
+
+var durationUnits = []struct {
+	d          time.Duration
+	short      string
+	long       string
+	longPlural string
+}{
+	{24 * time.Hour, "d", "day", "days"},
+	{time.Hour, "h", "hour", "hours"},
+	{time.Minute, "m", "minute", "minutes"},
+	{time.Second, "s", "second", "seconds"},
+	{time.Millisecond, "ms", "millisecond", "milliseconds"},
+}
+
+// FormatDuration 将时长格式化为便于阅读的文本, 如 "2h 3m", "1d 4h" 或 "3 days", 一天固定按 24 小时计算, 值为 0 的单位不会输出.
+// 时长不足最小输出单位时输出 0 个最小单位, 如 "0s"; 负数时长带有 "-" 前缀.
+func FormatDuration(d time.Duration, f DurationFormat) string {
+	maxUnits := f.MaxUnits
+	if maxUnits <= 0 {
+		maxUnits = 2
+	}
+	minUnit := f.MinUnit
+	if minUnit == 0 {
+		minUnit = time.Second
+	}
+	last := len(durationUnits) - 1
+	for last > 0 && durationUnits[last].d < minUnit {
+		last--
+	}
+
+	// 保留 d 的符号计算, 只对各单位的计数取反, 因为 -math.MinInt64 会溢出
+	sign, neg := "", int64(1)
+	if d < 0 {
+		sign, neg = "-", -1
+	}
+	first := 0
+	for first < last && d/durationUnits[first].d == 0 {
+		first++
+	}
+	precision := durationUnits[min(first+maxUnits-1, last)].d
+	if f.Round {
+		d = d.Round(precision)
+	} else {
+		d = d.Truncate(precision)
+	}
+
+	var parts []string
+	for _, u := range durationUnits[:last+1] {
+		if u.d < precision {
+			break
+		}
+		n := d / u.d
+		d -= n * u.d
+		if n != 0 {
+			parts = append(parts, formatDurationUnit(neg*int64(n), u.short, u.long, u.longPlural, f.Long))
+		}
+	}
+	if len(parts) == 0 {
+		u := durationUnits[last]
+		return formatDurationUnit(0, u.short, u.long, u.longPlural, f.Long)
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+func formatDurationUnit(n int64, short, long, longPlural string, isLong bool) string {
+	switch {
+	case !isLong:
+		return strconv.FormatInt(n, 10) + short
+	case n == 1:
+		return "1 " + long
+	default:
+		return strconv.FormatInt(n, 10) + " " + longPlural
+	}
+}
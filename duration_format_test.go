@@ -0,0 +1,35 @@
+package timex
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d    time.Duration
+		f    DurationFormat
+		want string
+	}{
+		{2*time.Hour + 3*time.Minute + 4*time.Second, DurationFormat{}, "2h 3m"},
+		{28 * time.Hour, DurationFormat{}, "1d 4h"},
+		{72 * time.Hour, DurationFormat{Long: true}, "3 days"},
+		{25*time.Hour + time.Minute, DurationFormat{Long: true, MaxUnits: 3}, "1 day 1 hour 1 minute"},
+		{90 * time.Minute, DurationFormat{MaxUnits: 1, Round: true}, "2h"},
+		{90 * time.Minute, DurationFormat{MaxUnits: 1}, "1h"},
+		{1500 * time.Millisecond, DurationFormat{MinUnit: time.Millisecond}, "1s 500ms"},
+		{500 * time.Millisecond, DurationFormat{}, "0s"},
+		{0, DurationFormat{Long: true}, "0 seconds"},
+		{-(2*time.Hour + 30*time.Minute), DurationFormat{}, "-2h 30m"},
+		{-90 * time.Minute, DurationFormat{MaxUnits: 1, Round: true}, "-2h"},
+		{math.MaxInt64, DurationFormat{}, "106751d 23h"},
+		{math.MinInt64, DurationFormat{}, "-106751d 23h"},
+		{math.MinInt64, DurationFormat{MaxUnits: 5, MinUnit: time.Millisecond}, "-106751d 23h 47m 16s 854ms"},
+	}
+	for _, c := range cases {
+		if got := FormatDuration(c.d, c.f); got != c.want {
+			t.Errorf("FormatDuration(%v, %+v) = %q, want %q", c.d, c.f, got, c.want)
+		}
+	}
+}
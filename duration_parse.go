@@ -0,0 +1,131 @@
+package timex
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDuration 表示无法解析的时长
+var ErrInvalidDuration = errors.New("invalid duration")
+
+// ParseDuration 是 time.ParseDuration 的超集, 另外支持 "d" (天, 固定为 24 小时) 和 "w" (周, 固定为 7 天) 两个单位, 如 "1w2d4h", "1.5d".
+func ParseDuration(s string) (time.Duration, error) {
+	neg, segs, err := splitDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	// 各段带着符号解析, 使 math.MinInt64 这样只有负数才能表示的时长不会溢出
+	sign := ""
+	if neg {
+		sign = "-"
+	}
+	var total time.Duration
+	for _, seg := range segs {
+		var d time.Duration
+		switch seg.unit {
+		case "d", "w":
+			if d, err = time.ParseDuration(sign + seg.num + "h"); err != nil {
+				return 0, ErrInvalidDuration
+			}
+			n := time.Duration(24)
+			if seg.unit == "w" {
+				n *= 7
+			}
+			if d > math.MaxInt64/n || d < math.MinInt64/n {
+				return 0, ErrInvalidDuration
+			}
+			d *= n
+		default:
+			if d, err = time.ParseDuration(sign + seg.num + seg.unit); err != nil {
+				return 0, ErrInvalidDuration
+			}
+		}
+		if d > 0 && total > math.MaxInt64-d || d < 0 && total < math.MinInt64-d {
+			return 0, ErrInvalidDuration
+		}
+		total += d
+	}
+	return total, nil
+}
+
+// ParseDurationPeriod 与 ParseDuration 类似, 但另外支持 "y" (年) 和 "mo" (月) 两个单位, 并返回 Period, 如 "1y6mo", "2w3d12h".
+// 年, 月, 周, 天只能是整数, 周会换算为 7 天; 其余单位的部分按照 time.ParseDuration 解析后依次填入时, 分, 秒和纳秒.
+func ParseDurationPeriod(s string) (Period, error) {
+	neg, segs, err := splitDuration(s)
+	if err != nil {
+		return Period{}, err
+	}
+	var p Period
+	var rest time.Duration
+	for _, seg := range segs {
+		switch seg.unit {
+		case "y", "mo", "w", "d":
+			n, err := strconv.Atoi(seg.num)
+			if err != nil {
+				return Period{}, ErrInvalidDuration
+			}
+			switch seg.unit {
+			case "y":
+				p.Years += n
+			case "mo":
+				p.Months += n
+			case "w":
+				p.Days += 7 * n
+			default:
+				p.Days += n
+			}
+		default:
+			d, err := time.ParseDuration(seg.num + seg.unit)
+			if err != nil || rest > math.MaxInt64-d {
+				return Period{}, ErrInvalidDuration
+			}
+			rest += d
+		}
+	}
+	p.Hours = int(rest / time.Hour)
+	p.Minutes = int(rest % time.Hour / time.Minute)
+	p.Seconds = int(rest % time.Minute / time.Second)
+	p.Nanoseconds = int(rest % time.Second)
+	if neg {
+		p = p.Negate()
+	}
+	return p, nil
+}
+
+// durationSegment 是时长文本中的一段, 如 "1.5h" 中的 "1.5" 和 "h"
+type durationSegment struct {
+	num  string
+	unit string
+}
+
+// splitDuration 将时长文本拆分为符号和若干段, "0" 视为零时长
+func splitDuration(s string) (bool, []durationSegment, error) {
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg, s = s[0] == '-', s[1:]
+	}
+	if s == "0" {
+		return neg, nil, nil
+	}
+	if s == "" {
+		return false, nil, ErrInvalidDuration
+	}
+
+	var segs []durationSegment
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return false, nil, ErrInvalidDuration
+		}
+		j := strings.IndexFunc(s[i:], func(r rune) bool { return r >= '0' && r <= '9' || r == '.' })
+		if j < 0 {
+			j = len(s) - i
+		}
+		segs = append(segs, durationSegment{num: s[:i], unit: s[i : i+j]})
+		s = s[i+j:]
+	}
+	return neg, segs, nil
+}
@@ -0,0 +1,83 @@
+package timex
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"-0", 0},
+		{"1d", 24 * time.Hour},
+		{"1.5d", 36 * time.Hour},
+		{".5d", 12 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1w2d4h", 220 * time.Hour},
+		{"1d1d", 48 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"300ms", 300 * time.Millisecond},
+		{"1us1µs1ns", 2001 * time.Nanosecond},
+		{"+5m", 5 * time.Minute},
+		// 符号作用于整个时长
+		{"-1d12h", -36 * time.Hour},
+		{"-1w", -7 * 24 * time.Hour},
+		{"106751d", 106751 * 24 * time.Hour},
+		{"2562047h47m16.854775807s", math.MaxInt64},
+		{"-2562047h47m16.854775808s", math.MinInt64},
+		{"-9223372036854775808ns", math.MinInt64},
+	}
+	for _, c := range cases {
+		if got, err := ParseDuration(c.in); err != nil || got != c.want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", c.in, got, err, c.want)
+		}
+	}
+}
+
+func TestParseDurationInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "-", "+", "1", "d", "1x", "1mo", "1y", "1..5h", "1h-2m", "--1h", "1 h",
+		// 溢出
+		"106752d", "15251w", "2562048h", "2562047h47m16.854775808s", "-2562047h47m16.854775809s",
+		"2562047h2562047h", "9223372036854775808ns", "100000000000000000000d",
+	} {
+		if got, err := ParseDuration(s); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("ParseDuration(%q) = %v, %v, want ErrInvalidDuration", s, got, err)
+		}
+	}
+}
+
+func TestParseDurationPeriod(t *testing.T) {
+	cases := []struct {
+		in   string
+		want Period
+	}{
+		{"0", Period{}},
+		{"1y6mo", Period{Years: 1, Months: 6}},
+		// "mo" 是月, "m" 是分钟
+		{"1mo1m", Period{Months: 1, Minutes: 1}},
+		{"2w3d12h", Period{Days: 17, Hours: 12}},
+		{"1y2mo3d4h5m6.5s", Period{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{"90m", Period{Hours: 1, Minutes: 30}},
+		{"-1y2d3h", Period{Years: -1, Days: -2, Hours: -3}},
+		{"+1mo", Period{Months: 1}},
+	}
+	for _, c := range cases {
+		if got, err := ParseDurationPeriod(c.in); err != nil || got != c.want {
+			t.Errorf("ParseDurationPeriod(%q) = %+v, %v, want %+v", c.in, got, err, c.want)
+		}
+	}
+
+	// 年, 月, 周, 天只能是整数
+	for _, s := range []string{"", "1.5d", "1.5y", "0.5mo", "1.5w", "mo", "1x", "1y-1mo", "99999999999999999999y"} {
+		if got, err := ParseDurationPeriod(s); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("ParseDurationPeriod(%q) = %+v, %v, want ErrInvalidDuration", s, got, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// PairingOrder 表示有多个进入事件等待配对时, 离开事件与哪一个配对
+type PairingOrder int
+
+const (
+	// PairFIFO 与最早的进入事件配对, 即先进先出
+	PairFIFO PairingOrder = iota
+	// PairLIFO 与最晚的进入事件配对, 即后进先出
+	PairLIFO
+)
+
+// PairingPolicy 表示 PairDwell 的配对规则
+type PairingPolicy struct {
+	Order PairingOrder
+	// OpenUntil 不为零值时, 没有对应离开事件的进入事件以 OpenUntil 作为离开时间 (如统计截止时间), 否则这些进入事件会被丢弃
+	OpenUntil time.Time
+}
+
+// PairDwell 将进入事件与离开事件配对, 返回按进入时间排序的停留时间段 [进入, 离开).
+// 事件按时间顺序处理, 同一时刻的进入事件先于离开事件处理; 没有等待配对的进入事件时, 离开事件会被丢弃.
+// 进入与离开时刻相同的配对仍会消耗这两个事件, 但零长度的停留不会出现在结果中. entries 和 exits 可以无序, 不会被修改.
+func PairDwell(entries, exits []time.Time, policy PairingPolicy) []*TimeRange {
+	byTime := func(a, b time.Time) int { return a.Compare(b) }
+	in, out := slices.Clone(entries), slices.Clone(exits)
+	slices.SortFunc(in, byTime)
+	slices.SortFunc(out, byTime)
+
+	var dwells []*TimeRange
+	var pending []time.Time
+	i := 0
+	for _, exit := range out {
+		for ; i < len(in) && !in[i].After(exit); i++ {
+			pending = append(pending, in[i])
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		var entry time.Time
+		if policy.Order == PairLIFO {
+			entry, pending = pending[len(pending)-1], pending[:len(pending)-1]
+		} else {
+			entry, pending = pending[0], pending[1:]
+		}
+		if entry.Before(exit) {
+			dwells = append(dwells, &TimeRange{start: entry, end: exit, startInclusive: true})
+		}
+	}
+
+	if !policy.OpenUntil.IsZero() {
+		for _, entry := range append(pending, in[i:]...) {
+			if entry.Before(policy.OpenUntil) {
+				dwells = append(dwells, &TimeRange{start: entry, end: policy.OpenUntil, startInclusive: true})
+			}
+		}
+	}
+	slices.SortStableFunc(dwells, func(a, b *TimeRange) int { return a.start.Compare(b.start) })
+	return dwells
+}
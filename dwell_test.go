@@ -0,0 +1,30 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPairDwellSkipsZeroLength(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+	entries := []time.Time{at(0), at(10), at(30), at(60)}
+	exits := []time.Time{at(0), at(20)}
+
+	got := PairDwell(entries, exits, PairingPolicy{OpenUntil: at(60)})
+	want := []*TimeRange{
+		MustNewTimeRange(at(10), at(20), true, false),
+		MustNewTimeRange(at(30), at(60), true, false),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("PairDwell = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].String() != want[i].String() {
+			t.Errorf("PairDwell[%d] = %v, want %v", i, got[i], want[i])
+		}
+		if _, err := NewTimeRange(got[i].start, got[i].end, true, false); err != nil {
+			t.Errorf("PairDwell[%d] is not a valid range: %v", i, err)
+		}
+	}
+}
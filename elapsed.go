@@ -0,0 +1,104 @@
+package timex
+
+import (
+	"sync"
+	"time"
+)
+
+// elapsedJumpTolerance 是墙上时间比单调时间少走多少时才视为墙上时间被回拨, 用于容忍时钟同步造成的微小调整
+const elapsedJumpTolerance = time.Second
+
+// ElapsedRecord 是 ElapsedTracker 持久化的进度
+type ElapsedRecord struct {
+	Elapsed  time.Duration // 到最后一次记录时为止累计的运行时长
+	LastWall time.Time     // 最后一次记录时的墙上时间
+}
+
+// ElapsedStore 持久化 ElapsedTracker 的进度
+type ElapsedStore interface {
+	// Load 返回上次保存的进度, 没有记录时返回零值
+	Load() (ElapsedRecord, error)
+	// Save 保存进度
+	Save(r ElapsedRecord) error
+}
+
+// ElapsedTracker 跨越进程重启累计运行时长, 适合试用期这类不能被修改系统时间绕过的计时.
+// 进程运行期间的时长按照 Clock.Since 计算, 对于 RealClock 即单调时钟, 不受墙上时间调整的影响; 进程停止期间的时长不计入.
+// 它同时检测墙上时间的回拨: 启动时的墙上时间早于上次记录的墙上时间, 或运行期间墙上时间比单调时间少走了超过一秒,
+// 或早于本次启动时及上次记录时的墙上时间超过一秒 (适用于 FakeClock 这类没有单调时钟读数的 Clock). Clock.Since 变小时累计的运行时长保持不变, 不会减少.
+type ElapsedTracker struct {
+	mu        sync.Mutex
+	clock     Clock
+	store     ElapsedStore
+	base      ElapsedRecord
+	start     time.Time     // 本次启动时的当前时间, 对于 RealClock 带有单调时钟读数
+	ran       time.Duration // 本次运行已经计入的时长, 只增不减
+	wentBack  bool
+	lastCheck time.Time
+}
+
+// NewElapsedTracker 创建ElapsedTracker, 从 store 读取之前累计的运行时长
+func NewElapsedTracker(clock Clock, store ElapsedStore) (*ElapsedTracker, error) {
+	r, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	now := clock.Now()
+	return &ElapsedTracker{
+		clock:     clock,
+		store:     store,
+		base:      r,
+		start:     now,
+		wentBack:  !r.LastWall.IsZero() && now.Before(r.LastWall),
+		lastCheck: r.LastWall,
+	}, nil
+}
+
+// Elapsed 返回累计的运行时长, 包括之前各次运行和本次运行至今的时长
+func (t *ElapsedTracker) Elapsed() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.base.Elapsed + t.runTime()
+}
+
+// Checkpoint 保存当前累计的运行时长, 应当定期以及在进程退出前调用, 两次调用之间的运行时长在进程异常退出时会丢失
+func (t *ElapsedTracker) Checkpoint() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.detectJump()
+	now := t.clock.Now()
+	r := ElapsedRecord{Elapsed: t.base.Elapsed + t.runTime(), LastWall: now.Round(0)}
+	// 墙上时间被回拨后仍然保留较晚的记录, 使下次启动时也能发现回拨
+	if r.LastWall.Before(t.lastCheck) {
+		r.LastWall = t.lastCheck
+	}
+	if err := t.store.Save(r); err != nil {
+		return err
+	}
+	t.lastCheck = r.LastWall
+	return nil
+}
+
+// WallClockWentBackward 判断是否检测到墙上时间被回拨
+func (t *ElapsedTracker) WallClockWentBackward() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.detectJump()
+	return t.wentBack
+}
+
+// runTime 返回本次运行至今的时长, 不小于之前返回过的值, 调用方需要持有锁
+func (t *ElapsedTracker) runTime() time.Duration {
+	t.ran = max(t.ran, t.clock.Since(t.start))
+	return t.ran
+}
+
+// detectJump 比较本次运行至今的墙上时长与单调时长, 以及当前墙上时间与启动时和上次记录时的墙上时间, 调用方需要持有锁
+func (t *ElapsedTracker) detectJump() {
+	now := t.clock.Now().Round(0)
+	wall := now.Sub(t.start.Round(0))
+	if wall < t.clock.Since(t.start)-elapsedJumpTolerance || wall < -elapsedJumpTolerance ||
+		now.Before(t.lastCheck.Add(-elapsedJumpTolerance)) {
+		t.wentBack = true
+	}
+}
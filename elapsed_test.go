@@ -0,0 +1,134 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// memoryElapsedStore 是保存在内存中的 ElapsedStore
+type memoryElapsedStore struct {
+	r   ElapsedRecord
+	err error
+}
+
+func (s *memoryElapsedStore) Load() (ElapsedRecord, error) { return s.r, s.err }
+
+func (s *memoryElapsedStore) Save(r ElapsedRecord) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.r = r
+	return nil
+}
+
+func TestElapsedTrackerAcrossRestarts(t *testing.T) {
+	w0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryElapsedStore{r: ElapsedRecord{Elapsed: time.Hour, LastWall: w0}}
+	// 进程停止期间的 10 分钟不计入
+	clock := NewFakeClock(w0.Add(10 * time.Minute))
+	tracker, err := NewElapsedTracker(clock, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(30 * time.Minute)
+	if got := tracker.Elapsed(); got != 90*time.Minute {
+		t.Errorf("Elapsed = %v, want 1h30m", got)
+	}
+	if err := tracker.Checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	if want := (ElapsedRecord{Elapsed: 90 * time.Minute, LastWall: w0.Add(40 * time.Minute)}); store.r != want {
+		t.Errorf("saved %+v, want %+v", store.r, want)
+	}
+
+	clock.Advance(time.Hour)
+	restarted, err := NewElapsedTracker(clock, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restarted.Elapsed(); got != 90*time.Minute {
+		t.Errorf("Elapsed after restart = %v, want 1h30m", got)
+	}
+	if restarted.WallClockWentBackward() {
+		t.Error("WallClockWentBackward = true without a backward jump")
+	}
+}
+
+func TestElapsedTrackerBackwardJump(t *testing.T) {
+	w0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := &memoryElapsedStore{}
+	clock := NewFakeClock(w0)
+	tracker, err := NewElapsedTracker(clock, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Hour)
+	if err := tracker.Checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 不超过一秒的调整被容忍
+	clock.Set(clock.Now().Add(-500 * time.Millisecond))
+	if tracker.WallClockWentBackward() {
+		t.Error("WallClockWentBackward = true for a 500ms adjustment")
+	}
+
+	// FakeClock 没有单调时钟读数, 回拨后累计的运行时长不能减少
+	clock.Set(w0.Add(-30 * time.Minute))
+	if got := tracker.Elapsed(); got != time.Hour {
+		t.Errorf("Elapsed after jump = %v, want 1h", got)
+	}
+	if !tracker.WallClockWentBackward() {
+		t.Error("WallClockWentBackward = false after a backward jump")
+	}
+	clock.Advance(15 * time.Minute)
+	if err := tracker.Checkpoint(); err != nil {
+		t.Fatal(err)
+	}
+	// 保留较晚的墙上时间, 下次启动时仍能发现回拨
+	if want := (ElapsedRecord{Elapsed: time.Hour, LastWall: w0.Add(time.Hour)}); store.r != want {
+		t.Errorf("saved %+v, want %+v", store.r, want)
+	}
+	restarted, err := NewElapsedTracker(clock, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !restarted.WallClockWentBackward() {
+		t.Error("restarted tracker did not detect the backward jump")
+	}
+}
+
+func TestElapsedTrackerJumpBeforeFirstCheckpoint(t *testing.T) {
+	w0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(w0)
+	tracker, err := NewElapsedTracker(clock, &memoryElapsedStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.Set(w0.Add(-time.Minute))
+	if !tracker.WallClockWentBackward() {
+		t.Error("WallClockWentBackward = false after jumping before the start time")
+	}
+	if got := tracker.Elapsed(); got != 0 {
+		t.Errorf("Elapsed = %v, want 0", got)
+	}
+}
+
+func TestElapsedTrackerStoreErrors(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if _, err := NewElapsedTracker(clock, &memoryElapsedStore{err: errStore}); !errors.Is(err, errStore) {
+		t.Errorf("NewElapsedTracker error = %v, want %v", err, errStore)
+	}
+
+	store := &memoryElapsedStore{}
+	tracker, err := NewElapsedTracker(clock, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.err = errStore
+	if err := tracker.Checkpoint(); !errors.Is(err, errStore) {
+		t.Errorf("Checkpoint error = %v, want %v", err, errStore)
+	}
+}
@@ -0,0 +1,65 @@
+package timex
+
+import "time"
+
+// QuietHours 表示每天的免打扰时段 [From, To), From 晚于 To 时表示跨越零点, 如 22:00 到 07:00. From 与 To 相同时表示没有免打扰时段.
+type QuietHours struct {
+	From     TimeOfDay
+	To       TimeOfDay
+	Location *time.Location // 为 nil 时使用 UTC
+}
+
+// Contains 判断时间是否处于免打扰时段内
+func (q QuietHours) Contains(t time.Time) bool {
+	_, ok := q.endOf(t)
+	return ok
+}
+
+// endOf 返回包含 t 的免打扰时段的结束时间
+func (q QuietHours) endOf(t time.Time) (time.Time, bool) {
+	if q.From == q.To {
+		return time.Time{}, false
+	}
+	loc := q.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	d := DateOfByTz(t, loc)
+	for _, day := range []Date{d.AddDays(-1), d} {
+		start, endDay := q.From.On(day, loc), day
+		if q.From.After(q.To) {
+			endDay = day.AddDays(1)
+		}
+		if end := q.To.On(endDay, loc); !t.Before(start) && t.Before(end) {
+			return end, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// EscalationStep 表示升级策略中的一级
+type EscalationStep struct {
+	Delay             time.Duration // 距上一级触发 (第一级为事件开始) 的时长
+	BusinessHoursOnly bool          // 为 true 时 Delay 只计算工作时间, 需要提供工作日历
+	IgnoreQuietHours  bool          // 为 true 时即使处于免打扰时段也立即触发, 适用于最高级别的升级
+}
+
+// EscalationTimes 计算从事件开始时刻 incidentStart 起, 升级策略中每一级的触发时间.
+// 每一级在上一级触发后经过 Delay 触发, BusinessHoursOnly 的级别在 cal 不为 nil 时只在工作时间内计时;
+// 触发时间落在免打扰时段内的级别会推迟到免打扰时段结束, 除非设置了 IgnoreQuietHours. 推迟后的时间作为下一级的计时起点.
+func EscalationTimes(incidentStart time.Time, policy []EscalationStep, quiet QuietHours, cal *BusinessCalendar) []time.Time {
+	times := make([]time.Time, 0, len(policy))
+	t := incidentStart
+	for _, step := range policy {
+		if step.BusinessHoursOnly && cal != nil {
+			t = cal.addWorkingDuration(t, step.Delay)
+		} else {
+			t = t.Add(step.Delay)
+		}
+		if end, ok := quiet.endOf(t); ok && !step.IgnoreQuietHours {
+			t = end
+		}
+		times = append(times, t)
+	}
+	return times
+}
@@ -0,0 +1,88 @@
+package timex
+
+import "time"
+
+// Festival 根据规则计算节日在某一年的日期, 该年没有该节日 (或超出了计算支持的范围) 时返回 false.
+// 年份是节日自身所在历法的年份, 对于农历节日是农历年, 因此农历十一月, 十二月的节日可能落在下一个公历年初.
+type Festival func(year int) (Date, bool)
+
+var (
+	// MothersDay 母亲节, 5 月的第二个周日
+	MothersDay = NthWeekdayFestival(time.May, time.Sunday, 2)
+	// FathersDay 父亲节, 6 月的第三个周日
+	FathersDay = NthWeekdayFestival(time.June, time.Sunday, 3)
+	// Thanksgiving 美国感恩节, 11 月的第四个周四
+	Thanksgiving = NthWeekdayFestival(time.November, time.Thursday, 4)
+	// Easter 复活节 (西方教会), 按照格里高利历的计算方法确定
+	Easter Festival = easter
+	// SpringFestival 春节, 农历正月初一
+	SpringFestival = LunarFestival(1, 1)
+	// DragonBoatFestival 端午节, 农历五月初五
+	DragonBoatFestival = LunarFestival(5, 5)
+	// MidAutumnFestival 中秋节, 农历八月十五
+	MidAutumnFestival = LunarFestival(8, 15)
+)
+
+// FixedFestival 返回每年在 month 月 day 日的节日, 该日期不存在的年份 (如平年的 2 月 29 日) 没有该节日
+func FixedFestival(month time.Month, day int) Festival {
+	return func(year int) (Date, bool) {
+		d := Date{Year: year, Month: month, Day: day}
+		return d, d.IsValid()
+	}
+}
+
+// NthWeekdayFestival 返回每年在 month 月第 n 个星期 weekday 的节日, n 为 -1 时表示最后一个
+func NthWeekdayFestival(month time.Month, weekday time.Weekday, n int) Festival {
+	return func(year int) (Date, bool) {
+		if n == -1 {
+			return lastWeekdayDate(year, month, weekday), true
+		}
+		return nthWeekdayDate(year, month, weekday, n)
+	}
+}
+
+// LunarFestival 返回每个农历年在 month 月 day 日 (非闰月) 的节日, 按照 ChineseLunarCalendar 换算为公历, 没有该日期 (如小月的三十) 的年份没有该节日
+func LunarFestival(month, day int) Festival {
+	cal := ChineseLunarCalendar.(*chineseLunarCalendar)
+	return func(year int) (Date, bool) {
+		d, err := cal.toDate(CalendarFields{Year: year, Month: month, Day: day})
+		return d, err == nil
+	}
+}
+
+// Schedule 返回在每年的节日当天于 loc 中开始时触发的 Schedule, 连续十年都没有该节日时认为不会再触发
+func (f Festival) Schedule(loc *time.Location) Schedule {
+	return festivalSchedule{f: f, loc: loc}
+}
+
+type festivalSchedule struct {
+	f   Festival
+	loc *time.Location
+}
+
+func (s festivalSchedule) Next(after time.Time) (time.Time, bool) {
+	// 农历节日可能落在下一个公历年初, 因此从上一年开始检查
+	year := DateOfByTz(after, s.loc).Year
+	for y := year - 1; y < year+10; y++ {
+		if d, ok := s.f(y); ok {
+			if t := startOfLocalDay(d, s.loc); t.After(after) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// easter 使用匿名格里高利算法计算复活节
+func easter(year int) (Date, bool) {
+	a, b, c := year%19, year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	n := h + l - 7*m + 114
+	return Date{Year: year, Month: time.Month(n / 31), Day: n%31 + 1}, year > 1582
+}
@@ -0,0 +1,82 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFestivals(t *testing.T) {
+	cases := []struct {
+		name string
+		f    Festival
+		year int
+		want Date
+		ok   bool
+	}{
+		{"Easter", Easter, 2000, NewDate(2000, time.April, 23), true},
+		{"Easter", Easter, 2019, NewDate(2019, time.April, 21), true},
+		{"Easter", Easter, 2024, NewDate(2024, time.March, 31), true},
+		{"Easter", Easter, 2025, NewDate(2025, time.April, 20), true},
+		// 最晚和最早的复活节
+		{"Easter", Easter, 2038, NewDate(2038, time.April, 25), true},
+		{"Easter", Easter, 2285, NewDate(2285, time.March, 22), true},
+		{"Easter", Easter, 1582, Date{}, false},
+		{"MothersDay", MothersDay, 2024, NewDate(2024, time.May, 12), true},
+		{"FathersDay", FathersDay, 2024, NewDate(2024, time.June, 16), true},
+		{"Thanksgiving", Thanksgiving, 2024, NewDate(2024, time.November, 28), true},
+		{"last Monday of May", NthWeekdayFestival(time.May, time.Monday, -1), 2024, NewDate(2024, time.May, 27), true},
+		{"fifth Monday of February", NthWeekdayFestival(time.February, time.Monday, 5), 2024, Date{}, false},
+		{"fifth Thursday of February", NthWeekdayFestival(time.February, time.Thursday, 5), 2024, NewDate(2024, time.February, 29), true},
+		{"Feb 29", FixedFestival(time.February, 29), 2024, NewDate(2024, time.February, 29), true},
+		{"Feb 29", FixedFestival(time.February, 29), 2023, Date{}, false},
+		{"SpringFestival", SpringFestival, 2024, NewDate(2024, time.February, 10), true},
+		{"SpringFestival", SpringFestival, 2025, NewDate(2025, time.January, 29), true},
+		{"DragonBoatFestival", DragonBoatFestival, 2023, NewDate(2023, time.June, 22), true},
+		{"DragonBoatFestival", DragonBoatFestival, 2024, NewDate(2024, time.June, 10), true},
+		{"DragonBoatFestival", DragonBoatFestival, 2025, NewDate(2025, time.May, 31), true},
+		{"MidAutumnFestival", MidAutumnFestival, 2023, NewDate(2023, time.September, 29), true},
+		{"MidAutumnFestival", MidAutumnFestival, 2024, NewDate(2024, time.September, 17), true},
+		{"MidAutumnFestival", MidAutumnFestival, 2025, NewDate(2025, time.October, 6), true},
+		// 农历腊月的节日落在下一个公历年, 腊月是小月的年份没有三十
+		{"lunar 12-30", LunarFestival(12, 30), 2023, NewDate(2024, time.February, 9), true},
+		{"lunar 12-30", LunarFestival(12, 30), 2024, Date{}, false},
+		{"lunar 1-1 out of range", SpringFestival, 2200, Date{}, false},
+	}
+	for _, c := range cases {
+		got, ok := c.f(c.year)
+		if ok != c.ok || ok && got != c.want {
+			t.Errorf("%s(%d) = %v, %v, want %v, %v", c.name, c.year, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestFestivalSchedule(t *testing.T) {
+	sh := time.FixedZone("CST", 8*3600)
+	cases := []struct {
+		name  string
+		f     Festival
+		after time.Time
+		want  time.Time
+	}{
+		// 上一个农历年的节日可能在公历新年之后
+		{"SpringFestival", SpringFestival, time.Date(2024, 12, 31, 0, 0, 0, 0, sh), time.Date(2025, 1, 29, 0, 0, 0, 0, sh)},
+		{"SpringFestival", SpringFestival, time.Date(2024, 1, 1, 0, 0, 0, 0, sh), time.Date(2024, 2, 10, 0, 0, 0, 0, sh)},
+		{"lunar 12-30", LunarFestival(12, 30), time.Date(2024, 1, 1, 0, 0, 0, 0, sh), time.Date(2024, 2, 9, 0, 0, 0, 0, sh)},
+		// 恰好在节日开始时返回下一年
+		{"SpringFestival", SpringFestival, time.Date(2024, 2, 10, 0, 0, 0, 0, sh), time.Date(2025, 1, 29, 0, 0, 0, 0, sh)},
+		{"SpringFestival", SpringFestival, time.Date(2024, 2, 9, 16, 0, 0, 0, time.UTC), time.Date(2025, 1, 29, 0, 0, 0, 0, sh)},
+		{"SpringFestival", SpringFestival, time.Date(2024, 2, 9, 15, 59, 59, 0, time.UTC), time.Date(2024, 2, 10, 0, 0, 0, 0, sh)},
+		{"Feb 29", FixedFestival(time.February, 29), time.Date(2024, 3, 1, 0, 0, 0, 0, sh), time.Date(2028, 2, 29, 0, 0, 0, 0, sh)},
+		{"Easter", Easter, time.Date(2024, 4, 1, 0, 0, 0, 0, sh), time.Date(2025, 4, 20, 0, 0, 0, 0, sh)},
+	}
+	for _, c := range cases {
+		if got, ok := c.f.Schedule(sh).Next(c.after); !ok || !got.Equal(c.want) {
+			t.Errorf("%s.Next(%v) = %v, %v, want %v", c.name, c.after, got, ok, c.want)
+		}
+	}
+
+	// 连续十年都没有该节日时不再触发
+	if got, ok := FixedFestival(time.February, 30).Schedule(sh).Next(time.Date(2024, 1, 1, 0, 0, 0, 0, sh)); ok {
+		t.Errorf("Feb 30 Next = %v, want none", got)
+	}
+}
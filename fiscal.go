@@ -0,0 +1,107 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidFiscalStart 表示无效的财年开始月份
+var ErrInvalidFiscalStart = errors.New("invalid fiscal year start month")
+
+// FiscalCalendar 表示从某个月份开始的财年, 如从 4 月开始的日本及印度财年, 从 10 月开始的美国联邦财年.
+// 财季以 Quarter 表示, 其中 Year 为财年, Q 为财年中的第几个季度.
+type FiscalCalendar struct {
+	start          time.Month
+	namedByEndYear bool
+}
+
+// MustNewFiscalCalendar 创建FiscalCalendar, 如果参数无效则 panic
+func MustNewFiscalCalendar(start time.Month, namedByEndYear bool) *FiscalCalendar {
+	c, err := NewFiscalCalendar(start, namedByEndYear)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewFiscalCalendar 创建FiscalCalendar, 财年从每年的 start 月 1 日开始.
+// namedByEndYear 为 true 时以结束所在的公历年命名财年 (如美国联邦 2025 财年从 2024 年 10 月开始), 否则以开始所在的公历年命名 (如日本 2024 年度从 2024 年 4 月开始).
+func NewFiscalCalendar(start time.Month, namedByEndYear bool) (*FiscalCalendar, error) {
+	if start < time.January || start > time.December {
+		return nil, ErrInvalidFiscalStart
+	}
+	return &FiscalCalendar{start: start, namedByEndYear: namedByEndYear}, nil
+}
+
+// StartMonth 返回财年的开始月份
+func (c *FiscalCalendar) StartMonth() time.Month {
+	return c.start
+}
+
+// FiscalYearOf 返回时间在其自身时区中所属的财年
+func (c *FiscalCalendar) FiscalYearOf(t time.Time) int {
+	return c.FiscalYearOfDate(DateOf(t))
+}
+
+// FiscalYearOfByTz 返回时间在指定时区中所属的财年
+func (c *FiscalCalendar) FiscalYearOfByTz(t time.Time, loc *time.Location) int {
+	return c.FiscalYearOfDate(DateOfByTz(t, loc))
+}
+
+// FiscalYearOfDate 返回日期所属的财年
+func (c *FiscalCalendar) FiscalYearOfDate(d Date) int {
+	year := d.Year
+	if d.Month < c.start {
+		year--
+	}
+	if c.namedByEndYear && c.start != time.January {
+		year++
+	}
+	return year
+}
+
+// FiscalQuarterOf 返回时间在其自身时区中所属的财季
+func (c *FiscalCalendar) FiscalQuarterOf(t time.Time) Quarter {
+	return c.FiscalQuarterOfDate(DateOf(t))
+}
+
+// FiscalQuarterOfByTz 返回时间在指定时区中所属的财季
+func (c *FiscalCalendar) FiscalQuarterOfByTz(t time.Time, loc *time.Location) Quarter {
+	return c.FiscalQuarterOfDate(DateOfByTz(t, loc))
+}
+
+// FiscalQuarterOfDate 返回日期所属的财季
+func (c *FiscalCalendar) FiscalQuarterOfDate(d Date) Quarter {
+	return Quarter{Year: c.FiscalYearOfDate(d), Q: mod(int(d.Month)-int(c.start), 12)/3 + 1}
+}
+
+// YearDateRange 返回财年 year 的第一天至最后一天
+func (c *FiscalCalendar) YearDateRange(year int) *DateRange {
+	first := c.firstDay(year)
+	return &DateRange{start: first, end: first.AddMonths(12).AddDays(-1)}
+}
+
+// YearTimeRange 返回财年 year 在指定时区中从第一天零点 (包含) 到下一财年第一天零点 (不包含) 的 TimeRange
+func (c *FiscalCalendar) YearTimeRange(year int, loc *time.Location) *TimeRange {
+	return c.YearDateRange(year).ToTimeRange(loc)
+}
+
+// QuarterDateRange 返回财季的第一天至最后一天, 季度序号无效时按照 NewQuarter 的规则规范化
+func (c *FiscalCalendar) QuarterDateRange(q Quarter) *DateRange {
+	q = NewQuarter(q.Year, q.Q)
+	first := c.firstDay(q.Year).AddMonths(3 * (q.Q - 1))
+	return &DateRange{start: first, end: first.AddMonths(3).AddDays(-1)}
+}
+
+// QuarterTimeRange 返回财季在指定时区中从第一天零点 (包含) 到下一财季第一天零点 (不包含) 的 TimeRange
+func (c *FiscalCalendar) QuarterTimeRange(q Quarter, loc *time.Location) *TimeRange {
+	return c.QuarterDateRange(q).ToTimeRange(loc)
+}
+
+// firstDay 返回财年 year 的第一天
+func (c *FiscalCalendar) firstDay(year int) Date {
+	if c.namedByEndYear && c.start != time.January {
+		year--
+	}
+	return Date{Year: year, Month: c.start, Day: 1}
+}
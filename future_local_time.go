@@ -0,0 +1,141 @@
+package timex
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidFutureLocalTime 表示无法解析的 FutureLocalTime
+var ErrInvalidFutureLocalTime = errors.New("invalid future local time")
+
+const futureLocalLayout = "2006-01-02T15:04:05.999999999"
+
+// FutureLocalTime 表示某个时区中的墙上时间, 例如 "2030-03-01 10:00 Europe/Berlin".
+// 它只保存墙上时间和时区名称, 直到调用 Resolve 时才根据当时的 tzdata 解析为具体的时间点,
+// 因此适合存储未来的会议等时间: 若之后政府调整了夏令时规则, 解析结果会随之更新, 而不是停留在旧的偏移上.
+type FutureLocalTime struct {
+	wall time.Time // 墙上时间, 固定以 UTC 表示, 只有年月日时分秒字段有意义
+	zone string
+}
+
+// MustNewFutureLocalTime 创建FutureLocalTime, 如果参数无效则 panic
+func MustNewFutureLocalTime(wall time.Time, zone string) *FutureLocalTime {
+	ft, err := NewFutureLocalTime(wall, zone)
+	if err != nil {
+		panic(err)
+	}
+	return ft
+}
+
+// NewFutureLocalTime 创建FutureLocalTime, 墙上时间取自 wall 的年月日时分秒字段, wall 自身的时区会被忽略.
+// zone 必须是 time.LoadLocation 可以加载的时区名称.
+func NewFutureLocalTime(wall time.Time, zone string) (*FutureLocalTime, error) {
+	if _, err := time.LoadLocation(zone); err != nil {
+		return nil, err
+	}
+	year, month, day := wall.Date()
+	hour, min, sec := wall.Clock()
+	return &FutureLocalTime{
+		wall: time.Date(year, month, day, hour, min, sec, wall.Nanosecond(), time.UTC),
+		zone: zone,
+	}, nil
+}
+
+// ParseFutureLocalTime 解析 String 方法输出的格式, 如 "2030-03-01T10:00:00 Europe/Berlin"
+func ParseFutureLocalTime(s string) (*FutureLocalTime, error) {
+	wallStr, zone, ok := strings.Cut(s, " ")
+	if !ok {
+		return nil, ErrInvalidFutureLocalTime
+	}
+	wall, err := time.Parse(futureLocalLayout, wallStr)
+	if err != nil {
+		return nil, err
+	}
+	return NewFutureLocalTime(wall, zone)
+}
+
+// Wall 返回墙上时间, 其时区固定为 UTC, 只有年月日时分秒字段有意义
+func (ft *FutureLocalTime) Wall() time.Time {
+	return ft.wall
+}
+
+// Zone 返回时区名称
+func (ft *FutureLocalTime) Zone() string {
+	return ft.zone
+}
+
+// Resolve 使用当前加载的 tzdata 将墙上时间解析为时间点.
+// 墙上时间出现两次时返回较早的时间点, 墙上时间不存在时向后顺延跳过的时长.
+func (ft *FutureLocalTime) Resolve() (time.Time, error) {
+	loc, err := time.LoadLocation(ft.zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, month, day := ft.wall.Date()
+	hour, min, sec := ft.wall.Clock()
+	return resolveLocal(year, month, day, hour, min, sec, ft.wall.Nanosecond(), loc), nil
+}
+
+// String 返回 "墙上时间 时区名称" 形式的文本, 如 "2030-03-01T10:00:00 Europe/Berlin"
+func (ft FutureLocalTime) String() string {
+	return ft.wall.Format(futureLocalLayout) + " " + ft.zone
+}
+
+type futureLocalTimeJSON struct {
+	Local string `json:"local"`
+	Zone  string `json:"zone"`
+}
+
+// MarshalJSON 实现 json.Marshaler, 输出形如 {"local":"2030-03-01T10:00:00","zone":"Europe/Berlin"}
+func (ft FutureLocalTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(futureLocalTimeJSON{
+		Local: ft.wall.Format(futureLocalLayout),
+		Zone:  ft.zone,
+	})
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (ft *FutureLocalTime) UnmarshalJSON(data []byte) error {
+	var v futureLocalTimeJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	wall, err := time.Parse(futureLocalLayout, v.Local)
+	if err != nil {
+		return err
+	}
+	t, err := NewFutureLocalTime(wall, v.Zone)
+	if err != nil {
+		return err
+	}
+	*ft = *t
+	return nil
+}
+
+// Value 实现 driver.Valuer, 以 String 方法的格式存储为文本
+func (ft FutureLocalTime) Value() (driver.Value, error) {
+	return ft.String(), nil
+}
+
+// Scan 实现 sql.Scanner, 支持从 string 或 []byte 中读取 String 方法的格式
+func (ft *FutureLocalTime) Scan(src any) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("timex: cannot scan %T into FutureLocalTime", src)
+	}
+	t, err := ParseFutureLocalTime(s)
+	if err != nil {
+		return err
+	}
+	*ft = *t
+	return nil
+}
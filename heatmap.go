@@ -0,0 +1,43 @@
+package timex
+
+import "time"
+
+// HeatmapCell 表示日历热力图 (类似 GitHub 贡献图) 中的一格
+type HeatmapCell struct {
+	Date   Date
+	Count  int
+	Week   ISOWeek // 日期所属的 ISO 周, 年初和年末的几天可能属于相邻年份的 ISO 周
+	Column int     // 列序号, 从 0 开始, 每列为周一至周日的一周, 第 0 列包含 1 月 1 日
+	Row    int     // 行序号, 周一为 0, 周日为 6
+}
+
+// CalendarHeatmap 按照 loc 中的日期统计 year 年每天的时间点个数, 结果包含该年的每一天, 没有时间点的日期计为 0, 不在该年的时间点会被忽略
+func CalendarHeatmap(times []time.Time, year int, loc *time.Location) map[Date]int {
+	counts := map[Date]int{}
+	for d := (Date{Year: year, Month: time.January, Day: 1}); d.Year == year; d = d.AddDays(1) {
+		counts[d] = 0
+	}
+	for _, t := range times {
+		if d := DateOfByTz(t, loc); d.Year == year {
+			counts[d]++
+		}
+	}
+	return counts
+}
+
+// HeatmapCells 将 CalendarHeatmap 的结果按照热力图的布局展开为 year 年每一天的格子, 按日期排序, counts 中没有的日期计为 0
+func HeatmapCells(counts map[Date]int, year int) []HeatmapCell {
+	first := Date{Year: year, Month: time.January, Day: 1}
+	firstMonday := truncateDate(first, UnitWeek)
+	var cells []HeatmapCell
+	for d := first; d.Year == year; d = d.AddDays(1) {
+		cells = append(cells, HeatmapCell{
+			Date:   d,
+			Count:  counts[d],
+			Week:   ISOWeekOfDate(d),
+			Column: d.DaysSince(firstMonday) / 7,
+			Row:    (int(d.Weekday()) + 6) % 7,
+		})
+	}
+	return cells
+}
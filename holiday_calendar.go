@@ -0,0 +1,48 @@
+package timex
+
+import "slices"
+
+// HolidayCalendar 提供节假日数据, 可以通过 BusinessCalendar.SetHolidayCalendar 接入公司或地区特有的节假日
+type HolidayCalendar interface {
+	// IsHoliday 判断日期是否为节假日
+	IsHoliday(d Date) bool
+	// HolidaysIn 返回日期范围内的所有节假日, 按日期升序排列
+	HolidaysIn(dr *DateRange) []Date
+}
+
+// StaticHolidayCalendar 是基于固定日期列表的 HolidayCalendar 实现
+type StaticHolidayCalendar struct {
+	dates map[Date]struct{}
+}
+
+// NewStaticHolidayCalendar 创建StaticHolidayCalendar, dates 为节假日
+func NewStaticHolidayCalendar(dates ...Date) *StaticHolidayCalendar {
+	c := &StaticHolidayCalendar{dates: map[Date]struct{}{}}
+	c.Add(dates...)
+	return c
+}
+
+// Add 添加节假日
+func (c *StaticHolidayCalendar) Add(dates ...Date) {
+	for _, d := range dates {
+		c.dates[d] = struct{}{}
+	}
+}
+
+// IsHoliday 判断日期是否为节假日
+func (c *StaticHolidayCalendar) IsHoliday(d Date) bool {
+	_, ok := c.dates[d]
+	return ok
+}
+
+// HolidaysIn 返回日期范围内的所有节假日, 按日期升序排列
+func (c *StaticHolidayCalendar) HolidaysIn(dr *DateRange) []Date {
+	var dates []Date
+	for d := range c.dates {
+		if dr.Contains(d) {
+			dates = append(dates, d)
+		}
+	}
+	slices.SortFunc(dates, Date.Compare)
+	return dates
+}
@@ -0,0 +1,172 @@
+package timex
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidISOWeek 表示无效的 ISO 周
+var ErrInvalidISOWeek = errors.New("invalid iso week")
+
+// ISOWeek 表示 ISO 8601 定义的周, 由 ISO 年份和周数组成, 每周从周一开始.
+// 文本及 JSON 编码格式为 "2024-W05".
+type ISOWeek struct {
+	Year int
+	Week int
+}
+
+// ISOWeekOf 返回时间在其自身时区中所属的 ISO 周
+func ISOWeekOf(t time.Time) ISOWeek {
+	year, week := t.ISOWeek()
+	return ISOWeek{Year: year, Week: week}
+}
+
+// ISOWeekOfByTz 返回时间在指定时区中所属的 ISO 周
+func ISOWeekOfByTz(t time.Time, loc *time.Location) ISOWeek {
+	return ISOWeekOf(t.In(loc))
+}
+
+// ISOWeekOfDate 返回日期所属的 ISO 周
+func ISOWeekOfDate(d Date) ISOWeek {
+	return ISOWeekOf(d.In(time.UTC))
+}
+
+// ParseISOWeek 解析 "2024-W05" 格式的 ISO 周, 年份和周数必须分别是 4 位和 2 位数字
+func ParseISOWeek(s string) (ISOWeek, error) {
+	if len(s) != 8 || s[4:6] != "-W" || !isASCIIDigits(s[:4]) || !isASCIIDigits(s[6:]) {
+		return ISOWeek{}, ErrInvalidISOWeek
+	}
+	year, err1 := strconv.Atoi(s[:4])
+	week, err2 := strconv.Atoi(s[6:])
+	w := ISOWeek{Year: year, Week: week}
+	if err1 != nil || err2 != nil || !w.IsValid() {
+		return ISOWeek{}, ErrInvalidISOWeek
+	}
+	return w, nil
+}
+
+// IsValid 判断周数是否在该 ISO 年份的范围内
+func (w ISOWeek) IsValid() bool {
+	return w.Week >= 1 && w.Week <= WeeksInYear(w.Year)
+}
+
+// Monday 返回该周的周一
+func (w ISOWeek) Monday() Date {
+	// 1 月 4 日总是在第 1 周
+	jan4 := Date{Year: w.Year, Month: time.January, Day: 4}
+	return jan4.AddDays(-(int(jan4.Weekday())+6)%7 + (w.Week-1)*7)
+}
+
+// Day 返回该周中星期为 weekday 的日期, 周日是该周的最后一天
+func (w ISOWeek) Day(weekday time.Weekday) Date {
+	return w.Monday().AddDays((int(weekday) + 6) % 7)
+}
+
+// Sunday 返回该周的周日
+func (w ISOWeek) Sunday() Date {
+	return w.Monday().AddDays(6)
+}
+
+// Next 返回下一周
+func (w ISOWeek) Next() ISOWeek {
+	return w.AddWeeks(1)
+}
+
+// Prev 返回上一周
+func (w ISOWeek) Prev() ISOWeek {
+	return w.AddWeeks(-1)
+}
+
+// AddWeeks 返回 n 周之后的 ISO 周, n 可以为负数
+func (w ISOWeek) AddWeeks(n int) ISOWeek {
+	return ISOWeekOfDate(w.Monday().AddDays(7 * n))
+}
+
+// Contains 判断日期是否在该周内
+func (w ISOWeek) Contains(d Date) bool {
+	return ISOWeekOfDate(d) == w
+}
+
+// Compare 比较两个 ISO 周, w 早于 other 时返回 -1, 晚于时返回 1, 相同时返回 0
+func (w ISOWeek) Compare(other ISOWeek) int {
+	if w.Year != other.Year {
+		return cmp.Compare(w.Year, other.Year)
+	}
+	return cmp.Compare(w.Week, other.Week)
+}
+
+// Before 判断 w 是否早于 other
+func (w ISOWeek) Before(other ISOWeek) bool {
+	return w.Compare(other) < 0
+}
+
+// After 判断 w 是否晚于 other
+func (w ISOWeek) After(other ISOWeek) bool {
+	return w.Compare(other) > 0
+}
+
+// ToDateRange 转换为周一至周日的 DateRange
+func (w ISOWeek) ToDateRange() *DateRange {
+	return &DateRange{start: w.Monday(), end: w.Sunday()}
+}
+
+// ToTimeRange 转换为指定时区中从周一零点 (包含) 到下周一零点 (不包含) 的 TimeRange
+func (w ISOWeek) ToTimeRange(loc *time.Location) *TimeRange {
+	return w.ToDateRange().ToTimeRange(loc)
+}
+
+// String 返回 "2024-W05" 格式的文本
+func (w ISOWeek) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (w ISOWeek) MarshalText() ([]byte, error) {
+	if !w.IsValid() {
+		return nil, ErrInvalidISOWeek
+	}
+	return []byte(w.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (w *ISOWeek) UnmarshalText(text []byte) error {
+	v, err := ParseISOWeek(string(text))
+	if err != nil {
+		return err
+	}
+	*w = v
+	return nil
+}
+
+// WeeksInYear 返回 ISO 年份包含的周数 (52 或 53), 12 月 28 日总是在该年的最后一周
+func WeeksInYear(year int) int {
+	return ISOWeekOfDate(Date{Year: year, Month: time.December, Day: 28}).Week
+}
+
+// FirstISOWeekStart 返回 ISO 年份第 1 周的周一, 可能在上一个公历年中, 如 2025 年第 1 周从 2024-12-30 开始
+func FirstISOWeekStart(year int) Date {
+	return ISOWeek{Year: year, Week: 1}.Monday()
+}
+
+// ISOWeekDate 返回 ISO 年份 year 第 week 周的星期 weekday 对应的日期, 周数超出该年的范围时返回 ErrInvalidISOWeek
+func ISOWeekDate(year, week int, weekday time.Weekday) (Date, error) {
+	w := ISOWeek{Year: year, Week: week}
+	if !w.IsValid() || weekday < time.Sunday || weekday > time.Saturday {
+		return Date{}, ErrInvalidISOWeek
+	}
+	return w.Day(weekday), nil
+}
+
+// ISOWeekDateOf 返回日期所属的 ISO 周和星期, 与 ISOWeekDate 互为逆运算
+func ISOWeekDateOf(d Date) (ISOWeek, time.Weekday) {
+	return ISOWeekOfDate(d), d.Weekday()
+}
+
+// isASCIIDigits 判断 s 是否非空且只由 ASCII 数字组成
+func isASCIIDigits(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
@@ -0,0 +1,42 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseISOWeek(t *testing.T) {
+	valid := map[string]ISOWeek{
+		"2024-W05": {Year: 2024, Week: 5},
+		"2020-W53": {Year: 2020, Week: 53},
+		"0024-W01": {Year: 24, Week: 1},
+	}
+	for s, want := range valid {
+		if got, err := ParseISOWeek(s); err != nil || got != want {
+			t.Errorf("ParseISOWeek(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"+024-W05", "-024-W05", " 024-W05", "2024-W+5", "2024-W-5", "2024-W 5", "2024-W00", "2021-W53", "2024W05", "2024-w05", "２０２４-W05"} {
+		if _, err := ParseISOWeek(s); !errors.Is(err, ErrInvalidISOWeek) {
+			t.Errorf("ParseISOWeek(%q) error = %v, want ErrInvalidISOWeek", s, err)
+		}
+	}
+}
+
+func TestISOWeekDays(t *testing.T) {
+	// 2021-01-01 是周五, 属于 2020 年第 53 周
+	w := ISOWeekOfDate(Date{2021, 1, 1})
+	if w != (ISOWeek{Year: 2020, Week: 53}) {
+		t.Fatalf("ISOWeekOfDate(2021-01-01) = %v", w)
+	}
+	if got := w.Monday(); got != (Date{2020, 12, 28}) {
+		t.Errorf("Monday = %v, want 2020-12-28", got)
+	}
+	if got := w.Sunday(); got != (Date{2021, 1, 3}) {
+		t.Errorf("Sunday = %v, want 2021-01-03", got)
+	}
+	if WeeksInYear(2020) != 53 || WeeksInYear(2021) != 52 {
+		t.Errorf("WeeksInYear(2020, 2021) = %d, %d, want 53, 52", WeeksInYear(2020), WeeksInYear(2021))
+	}
+}
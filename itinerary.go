@@ -0,0 +1,24 @@
+package timex
+
+import "time"
+
+// FlightDuration 返回从出发地当地时间 depLocal 到目的地当地时间 arrLocal 实际经过的时长, 如航班时刻表上的飞行时间.
+// depLocal 和 arrLocal 只使用墙上时间字段, 分别按照 depZone 和 arrZone 解析, 规则与 TimeOfDay.On 相同, 其自身的时区会被忽略.
+func FlightDuration(depLocal time.Time, depZone *time.Location, arrLocal time.Time, arrZone *time.Location) time.Duration {
+	return inZone(arrLocal, arrZone).Sub(inZone(depLocal, depZone))
+}
+
+// ArrivalLocal 返回从 dep 出发经过 dur 之后到达时目的地 arrZone 的当地时间
+func ArrivalLocal(dep time.Time, dur time.Duration, arrZone *time.Location) time.Time {
+	return dep.Add(dur).In(arrZone)
+}
+
+// DayChange 返回 arr 在其自身时区中的日期相对于 dep 在其自身时区中的日期相差的天数, 即时刻表上 "+1", "-1" 之类的跨日标记
+func DayChange(dep, arr time.Time) int {
+	return DateOf(arr).DaysSince(DateOf(dep))
+}
+
+// inZone 将 t 的墙上时间字段按照 loc 解析为时间点
+func inZone(t time.Time, loc *time.Location) time.Time {
+	return TimeOfDayOf(t).On(DateOf(t), loc)
+}
@@ -0,0 +1,64 @@
+package timex
+
+import "time"
+
+// LabeledRange 表示带有标签的时间范围, 如某人的值班时间段, 某个任务的执行窗口
+type LabeledRange[L any] struct {
+	Range *TimeRange
+	Label L
+}
+
+// layeredRange 是 resolveLayers 的结果, layer 为产生该标签的层序号
+type layeredRange[L comparable] struct {
+	LabeledRange[L]
+	layer int
+}
+
+// resolveLayers 在 window 内按层叠加多层带标签的时间范围, 序号大的层覆盖序号小的层, 同一层内靠后的范围覆盖靠前的范围.
+// 结果按时间排序且互不重叠, 没有任何范围覆盖的时间不会出现在结果中, 相邻且标签和层都相同的时间段会被合并.
+func resolveLayers[L comparable](layers [][]LabeledRange[L], window *TimeRange) []layeredRange[L] {
+	all := []*TimeRange{window}
+	for _, layer := range layers {
+		for _, lr := range layer {
+			all = append(all, lr.Range)
+		}
+	}
+
+	var result []layeredRange[L]
+	var runStart depthPiece
+	for _, p := range depthProfile(all) {
+		if !pieceInRange(p, window) {
+			continue
+		}
+		probe := p.start
+		if !p.point {
+			probe = p.start.Add(p.end.Sub(p.start) / 2)
+		}
+		label, layer, ok := topLabelAt(layers, probe)
+		if !ok {
+			continue
+		}
+
+		n := len(result)
+		if n > 0 && result[n-1].layer == layer && result[n-1].Label == label && result[n-1].Range.end.Equal(p.start) &&
+			(result[n-1].Range.endInclusive != p.point) {
+			result[n-1].Range = piecesToTimeRange(runStart, p)
+			continue
+		}
+		runStart = p
+		result = append(result, layeredRange[L]{LabeledRange: LabeledRange[L]{Range: piecesToTimeRange(p, p), Label: label}, layer: layer})
+	}
+	return result
+}
+
+// topLabelAt 返回 t 时最上层的标签
+func topLabelAt[L comparable](layers [][]LabeledRange[L], t time.Time) (label L, layer int, ok bool) {
+	for i := len(layers) - 1; i >= 0; i-- {
+		for j := len(layers[i]) - 1; j >= 0; j-- {
+			if layers[i][j].Range.Contains(t) {
+				return layers[i][j].Label, i, true
+			}
+		}
+	}
+	return label, 0, false
+}
@@ -0,0 +1,50 @@
+package timex
+
+import "time"
+
+// Assignment 表示有效的值班安排及其来源
+type Assignment struct {
+	Range *TimeRange
+	Who   string
+	Layer string // 产生该安排的层名称, 来自基础轮换时为空字符串
+}
+
+// LayeredSchedule 在基础轮换之上叠加若干层临时调整 (如换班, 临时顶班), 上层覆盖下层.
+type LayeredSchedule struct {
+	base   *Rotation
+	names  []string
+	layers [][]LabeledRange[string]
+}
+
+// NewLayeredSchedule 创建LayeredSchedule, base 为最底层的基础轮换, 其自身的临时调整视为基础层的一部分
+func NewLayeredSchedule(base *Rotation) *LayeredSchedule {
+	return &LayeredSchedule{base: base}
+}
+
+// AddLayer 在最上方添加一层调整, 层内每个范围以值班人为标签, 同一层内靠后的范围优先
+func (s *LayeredSchedule) AddLayer(name string, overrides ...LabeledRange[string]) {
+	s.names = append(s.names, name)
+	s.layers = append(s.layers, overrides)
+}
+
+// AssignmentAt 返回 t 时的值班人和产生该安排的层名称, 来自基础轮换时层名称为空字符串
+func (s *LayeredSchedule) AssignmentAt(t time.Time) (who string, layer string) {
+	if label, i, ok := topLabelAt(s.layers, t); ok {
+		return label, s.names[i]
+	}
+	return s.base.OnCallAt(t), ""
+}
+
+// Assignments 返回 window 内的有效值班安排, 按时间排序且互不重叠, 相邻且值班人和来源层都相同的时间段会被合并
+func (s *LayeredSchedule) Assignments(window *TimeRange) []Assignment {
+	layers := append([][]LabeledRange[string]{s.base.Shifts(window)}, s.layers...)
+	resolved := resolveLayers(layers, window)
+	assignments := make([]Assignment, len(resolved))
+	for i, lr := range resolved {
+		assignments[i] = Assignment{Range: lr.Range, Who: lr.Label}
+		if lr.layer > 0 {
+			assignments[i].Layer = s.names[lr.layer-1]
+		}
+	}
+	return assignments
+}
@@ -0,0 +1,14 @@
+package timex
+
+// Locale 表示输出文本使用的语言, 目前支持英文和简体中文, 其他值均按英文处理
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en"
+	LocaleChinese Locale = "zh-CN"
+)
+
+// isChinese 判断是否使用简体中文输出
+func (l Locale) isChinese() bool {
+	return l == LocaleChinese
+}
@@ -0,0 +1,220 @@
+package timex
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+)
+
+// ErrInvalidMatcher 表示无法解析的 Matcher
+var ErrInvalidMatcher = errors.New("invalid matcher")
+
+const (
+	matchAfter             = "after"
+	matchBefore            = "before"
+	matchInRange           = "inRange"
+	matchOnWeekday         = "onWeekday"
+	matchBetweenTimesOfDay = "betweenTimesOfDay"
+	matchAnd               = "and"
+	matchOr                = "or"
+	matchNot               = "not"
+)
+
+// Matcher 是可组合的时间条件, 用于按时间过滤事件, 如 "工作日 09:00 到 18:00 之间且在活动期内".
+// Matcher 可以编码为 JSON 并原样解码, 便于在规则引擎中存储.
+type Matcher struct {
+	op       string
+	t        time.Time
+	r        *TimeRange
+	weekdays []time.Weekday
+	from     TimeOfDay
+	to       TimeOfDay
+	loc      *time.Location
+	args     []*Matcher
+}
+
+// After 匹配晚于 t 的时间
+func After(t time.Time) *Matcher {
+	return &Matcher{op: matchAfter, t: t}
+}
+
+// Before 匹配早于 t 的时间
+func Before(t time.Time) *Matcher {
+	return &Matcher{op: matchBefore, t: t}
+}
+
+// InRange 匹配落在时间范围内的时间
+func InRange(tr *TimeRange) *Matcher {
+	return &Matcher{op: matchInRange, r: tr}
+}
+
+// OnWeekday 匹配星期为 days 之一的时间. 默认按照被匹配时间自身的时区判断, 可以通过 In 指定时区.
+func OnWeekday(days ...time.Weekday) *Matcher {
+	return &Matcher{op: matchOnWeekday, weekdays: days}
+}
+
+// BetweenTimesOfDay 匹配时刻在 [from, to) 之间的时间, from 晚于 to 时表示跨越零点, 如 22:00 到 06:00.
+// 默认按照被匹配时间自身的时区判断, 可以通过 In 指定时区.
+func BetweenTimesOfDay(from, to TimeOfDay) *Matcher {
+	return &Matcher{op: matchBetweenTimesOfDay, from: from, to: to}
+}
+
+// And 匹配同时满足所有条件的时间, 没有条件时匹配任何时间
+func And(matchers ...*Matcher) *Matcher {
+	return &Matcher{op: matchAnd, args: matchers}
+}
+
+// Or 匹配至少满足一个条件的时间, 没有条件时不匹配任何时间
+func Or(matchers ...*Matcher) *Matcher {
+	return &Matcher{op: matchOr, args: matchers}
+}
+
+// Not 匹配不满足条件的时间
+func Not(m *Matcher) *Matcher {
+	return &Matcher{op: matchNot, args: []*Matcher{m}}
+}
+
+// In 返回一个在指定时区中判断星期和时刻的 Matcher 副本, 只影响 OnWeekday 和 BetweenTimesOfDay 的判断.
+// 时区作用于整个 Matcher 树: And, Or, Not 会把转换到该时区的时间传给所有子条件, 子条件自身通过 In 指定的时区优先.
+func (m *Matcher) In(loc *time.Location) *Matcher {
+	c := *m
+	c.loc = loc
+	return &c
+}
+
+// Match 判断时间是否满足条件
+func (m *Matcher) Match(t time.Time) bool {
+	if m.loc != nil {
+		t = t.In(m.loc)
+	}
+	switch m.op {
+	case matchAfter:
+		return t.After(m.t)
+	case matchBefore:
+		return t.Before(m.t)
+	case matchInRange:
+		return m.r.Contains(t)
+	case matchOnWeekday:
+		return slices.Contains(m.weekdays, t.Weekday())
+	case matchBetweenTimesOfDay:
+		return TimeOfDayRange{From: m.from, To: m.to}.Contains(TimeOfDayOf(t))
+	case matchAnd:
+		for _, arg := range m.args {
+			if !arg.Match(t) {
+				return false
+			}
+		}
+		return true
+	case matchOr:
+		for _, arg := range m.args {
+			if arg.Match(t) {
+				return true
+			}
+		}
+		return false
+	case matchNot:
+		return !m.args[0].Match(t)
+	default:
+		return false
+	}
+}
+
+type matcherJSON struct {
+	Op       string     `json:"op"`
+	Time     *time.Time `json:"time,omitempty"`
+	Range    *TimeRange `json:"range,omitempty"`
+	Weekdays []string   `json:"weekdays,omitempty"`
+	From     *TimeOfDay `json:"from,omitempty"`
+	To       *TimeOfDay `json:"to,omitempty"`
+	Location string     `json:"location,omitempty"`
+	Args     []*Matcher `json:"args,omitempty"`
+}
+
+// MarshalJSON 实现 json.Marshaler, 如 {"op":"and","args":[{"op":"onWeekday","weekdays":["Monday"]},{"op":"after","time":"2024-01-01T00:00:00Z"}]}
+func (m *Matcher) MarshalJSON() ([]byte, error) {
+	v := matcherJSON{Op: m.op, Args: m.args}
+	switch m.op {
+	case matchAfter, matchBefore:
+		v.Time = &m.t
+	case matchInRange:
+		v.Range = m.r
+	case matchOnWeekday:
+		v.Weekdays = make([]string, len(m.weekdays))
+		for i, wd := range m.weekdays {
+			v.Weekdays[i] = wd.String()
+		}
+	case matchBetweenTimesOfDay:
+		v.From, v.To = &m.from, &m.to
+	}
+	if m.loc != nil {
+		v.Location = m.loc.String()
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (m *Matcher) UnmarshalJSON(data []byte) error {
+	var v matcherJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	r := Matcher{op: v.Op, args: v.Args}
+	switch v.Op {
+	case matchAfter, matchBefore:
+		if v.Time == nil {
+			return ErrInvalidMatcher
+		}
+		r.t = *v.Time
+	case matchInRange:
+		if v.Range == nil {
+			return ErrInvalidMatcher
+		}
+		r.r = v.Range
+	case matchOnWeekday:
+		for _, s := range v.Weekdays {
+			wd, ok := parseWeekday(s)
+			if !ok {
+				return ErrInvalidMatcher
+			}
+			r.weekdays = append(r.weekdays, wd)
+		}
+	case matchBetweenTimesOfDay:
+		if v.From == nil || v.To == nil {
+			return ErrInvalidMatcher
+		}
+		r.from, r.to = *v.From, *v.To
+	case matchAnd, matchOr:
+	case matchNot:
+		if len(v.Args) != 1 {
+			return ErrInvalidMatcher
+		}
+	default:
+		return ErrInvalidMatcher
+	}
+	for _, arg := range r.args {
+		if arg == nil {
+			return ErrInvalidMatcher
+		}
+	}
+	if v.Location != "" {
+		loc, err := time.LoadLocation(v.Location)
+		if err != nil {
+			return err
+		}
+		r.loc = loc
+	}
+	*m = r
+	return nil
+}
+
+// parseWeekday 解析 time.Weekday.String 输出的星期名称
+func parseWeekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if wd.String() == s {
+			return wd, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,32 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMatcherInAppliesToChildren(t *testing.T) {
+	cst := time.FixedZone("CST", 8*3600)
+	workHours := And(OnWeekday(time.Monday), Not(BetweenTimesOfDay(NewTimeOfDay(12, 0, 0), NewTimeOfDay(13, 0, 0))), BetweenTimesOfDay(NewTimeOfDay(9, 0, 0), NewTimeOfDay(18, 0, 0)))
+	cases := []struct {
+		t    time.Time
+		m    *Matcher
+		want bool
+	}{
+		// UTC 2024-04-29 02:00 是东八区周一 10:00
+		{time.Date(2024, 4, 29, 2, 0, 0, 0, time.UTC), workHours.In(cst), true},
+		{time.Date(2024, 4, 29, 2, 0, 0, 0, time.UTC), workHours, false},
+		// 东八区 12:30 被 Not 的子条件排除
+		{time.Date(2024, 4, 29, 4, 30, 0, 0, time.UTC), workHours.In(cst), false},
+		// UTC 2024-04-28 17:00 在东八区已经是周一, 但时刻是 01:00
+		{time.Date(2024, 4, 28, 17, 0, 0, 0, time.UTC), And(OnWeekday(time.Monday)).In(cst), true},
+		{time.Date(2024, 4, 28, 17, 0, 0, 0, time.UTC), workHours.In(cst), false},
+		// 子条件自身的时区优先
+		{time.Date(2024, 4, 28, 17, 0, 0, 0, time.UTC), Or(OnWeekday(time.Sunday).In(time.UTC)).In(cst), true},
+	}
+	for i, c := range cases {
+		if got := c.m.Match(c.t); got != c.want {
+			t.Errorf("case %d: Match(%v) = %v, want %v", i, c.t, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// ScoredHour 表示 BestCommonHours 中的一个候选小时
+type ScoredHour struct {
+	Start time.Time        // 该小时的开始时间, 位于 UTC
+	Score int              // 该小时完全落在可接受时段内的时区个数
+	Zones []*time.Location // 该小时完全落在可接受时段内的时区
+}
+
+// BestCommonHours 对 date 这一天 (按 UTC 计算) 的 24 个整点小时打分, 分数为该小时内的每个时刻都落在当地可接受时段 constraints 内的时区个数,
+// 结果按分数从高到低排列, 分数相同时按时间先后排列, 可以用来为跨时区的会议挑选时间.
+func BestCommonHours(zones []*time.Location, date Date, constraints TimeOfDayRange) []ScoredHour {
+	hours := make([]ScoredHour, 24)
+	day := date.In(time.UTC)
+	for h := range hours {
+		start := day.Add(time.Duration(h) * time.Hour)
+		hours[h] = ScoredHour{Start: start}
+		for _, loc := range zones {
+			if hourWithin(start, loc, constraints) {
+				hours[h].Score++
+				hours[h].Zones = append(hours[h].Zones, loc)
+			}
+		}
+	}
+	slices.SortStableFunc(hours, func(a, b ScoredHour) int { return b.Score - a.Score })
+	return hours
+}
+
+// hourWithin 判断从 start 开始的一小时在 loc 中的墙上时间是否完全落在 r 内, 夏令时切换使墙上时间跳跃时分段判断
+func hourWithin(start time.Time, loc *time.Location, r TimeOfDayRange) bool {
+	for _, seg := range zoneSegments(loc, start, start.Add(time.Hour)) {
+		if !r.containsSpan(TimeOfDayOf(seg.start.In(loc)), seg.end.Sub(seg.start)) {
+			return false
+		}
+	}
+	return true
+}
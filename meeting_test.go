@@ -0,0 +1,37 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBestCommonHours(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	date := Date{2024, 3, 1}
+	scores := func(zones []*time.Location, r TimeOfDayRange) map[int]int {
+		m := map[int]int{}
+		for _, h := range BestCommonHours(zones, date, r) {
+			m[h.Start.Hour()] = h.Score
+		}
+		return m
+	}
+
+	// 可接受时段从 10:40 跨越零点到次日 10:20, 排除的 10:20-10:40 完全位于 10:00-11:00 内部
+	wrap := TimeOfDayRange{From: NewTimeOfDay(10, 40, 0), To: NewTimeOfDay(10, 20, 0)}
+	got := scores([]*time.Location{time.UTC}, wrap)
+	if got[10] != 0 {
+		t.Errorf("hour 10:00 contains the excluded gap 10:20-10:40, score = %d, want 0", got[10])
+	}
+	if got[9] != 1 || got[11] != 1 {
+		t.Errorf("hours around the gap should score 1, got 09:00=%d 11:00=%d", got[9], got[11])
+	}
+
+	// 半小时时区中跨越可接受时段结束时刻的小时不计入
+	work := TimeOfDayRange{From: NewTimeOfDay(9, 0, 0), To: NewTimeOfDay(17, 0, 0)}
+	got = scores([]*time.Location{kolkata}, work)
+	for h, want := range map[int]int{3: 0, 4: 1, 10: 1, 11: 0} {
+		if got[h] != want {
+			t.Errorf("IST hour %02d:00 UTC score = %d, want %d", h, got[h], want)
+		}
+	}
+}
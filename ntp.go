@@ -0,0 +1,166 @@
+package timex
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"time"
+)
+
+// ErrInvalidNTPResponse 表示无法使用的 NTP 响应
+var ErrInvalidNTPResponse = errors.New("invalid ntp response")
+
+const (
+	ntpPacketSize = 48
+	// ntpEpochOffset 是 NTP 纪元 (1900-01-01) 到 unix 纪元的秒数
+	ntpEpochOffset = 2208988800
+	// ntpDefaultTimeout 是 ctx 没有截止时间时单次请求的超时时长
+	ntpDefaultTimeout = 5 * time.Second
+)
+
+// NTPTransport 负责与 NTP 服务器交换一个数据包, 可以替换为测试实现或自定义的网络实现
+type NTPTransport interface {
+	// Exchange 将 request 发送给 server 并返回其响应
+	Exchange(ctx context.Context, server string, request []byte) ([]byte, error)
+}
+
+// UDPTransport 是通过 UDP 访问 NTP 服务器的 NTPTransport 实现, server 没有端口时使用 123 端口
+type UDPTransport struct{}
+
+// Exchange 实现 NTPTransport
+func (UDPTransport) Exchange(ctx context.Context, server string, request []byte) ([]byte, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "123")
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(ntpDefaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, 2*ntpPacketSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// SkewReport 是对本地时钟相对于 NTP 服务器偏差的估计
+type SkewReport struct {
+	Server     string
+	Offset     time.Duration // 服务器时间减去本地时间, 为正数时表示本地时钟偏慢
+	RoundTrip  time.Duration // 采用的样本的网络往返时长, 偏差的误差不超过它的一半
+	Dispersion time.Duration // 各样本的偏差与采用的偏差之间的最大差值, 反映测量的稳定程度
+	Stratum    int           // 服务器的层级
+	Samples    int           // 成功的样本数
+}
+
+// Clock 返回按照估计的偏差校正 base 之后的 Clock, 即 OffsetClock(base, r.Offset)
+func (r SkewReport) Clock(base Clock) Clock {
+	return OffsetClock(base, r.Offset)
+}
+
+// NTPProbe 通过 NTP 协议 (SNTP, RFC 4330) 估计本地时钟的偏差
+type NTPProbe struct {
+	transport NTPTransport
+	clock     Clock
+}
+
+// NewNTPProbe 创建NTPProbe, 使用 clock 读取本地时间. transport 为 nil 时使用 UDPTransport, clock 为 nil 时使用 RealClock.
+func NewNTPProbe(transport NTPTransport, clock Clock) *NTPProbe {
+	if transport == nil {
+		transport = UDPTransport{}
+	}
+	if clock == nil {
+		clock = RealClock
+	}
+	return &NTPProbe{transport: transport, clock: clock}
+}
+
+// Probe 向 server 发送 samples 次请求 (不大于 0 时按 1 处理), 采用往返时长最短的样本作为偏差的估计.
+// 无效的响应 (如版本或模式不符, 层级为 0 的拒绝服务响应, 与请求不对应的响应) 会被忽略, 所有样本都失败时返回最后一个错误.
+func (p *NTPProbe) Probe(ctx context.Context, server string, samples int) (SkewReport, error) {
+	samples = max(samples, 1)
+	report := SkewReport{Server: server}
+	var offsets []time.Duration
+	var lastErr error
+	for i := 0; i < samples; i++ {
+		offset, rtt, stratum, err := p.sample(ctx, server)
+		if err != nil {
+			if ctx.Err() != nil {
+				return SkewReport{}, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+		offsets = append(offsets, offset)
+		if len(offsets) == 1 || rtt < report.RoundTrip {
+			report.Offset, report.RoundTrip, report.Stratum = offset, rtt, stratum
+		}
+	}
+	if len(offsets) == 0 {
+		return SkewReport{}, lastErr
+	}
+	for _, offset := range offsets {
+		diff := offset - report.Offset
+		if diff < 0 {
+			diff = -diff
+		}
+		report.Dispersion = max(report.Dispersion, diff)
+	}
+	report.Samples = len(offsets)
+	return report, nil
+}
+
+// sample 完成一次 NTP 请求, 返回偏差, 往返时长和服务器层级
+func (p *NTPProbe) sample(ctx context.Context, server string) (offset, rtt time.Duration, stratum int, err error) {
+	req := make([]byte, ntpPacketSize)
+	req[0] = 4<<3 | 3 // 版本 4, 客户端模式
+	t1 := p.clock.Now()
+	putNTPTime(req[40:], t1)
+
+	resp, err := p.transport.Exchange(ctx, server, req)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	t4 := p.clock.Now()
+	if len(resp) < ntpPacketSize {
+		return 0, 0, 0, ErrInvalidNTPResponse
+	}
+	// 版本为 1 到 4, 模式为 4 (服务器), 原始时间戳与请求的发送时间戳相同
+	if version := resp[0] >> 3 & 0x07; version < 1 || version > 4 || resp[0]&0x07 != 4 || resp[1] == 0 || string(resp[24:32]) != string(req[40:48]) {
+		return 0, 0, 0, ErrInvalidNTPResponse
+	}
+
+	t2, t3 := ntpTime(resp[32:]), ntpTime(resp[40:])
+	offset = (t2.Sub(t1) + t3.Sub(t4)) / 2
+	rtt = t4.Sub(t1) - t3.Sub(t2)
+	return offset, max(rtt, 0), int(resp[1]), nil
+}
+
+// putNTPTime 将时间写为 64 位 NTP 时间戳
+func putNTPTime(b []byte, t time.Time) {
+	sec := uint64(t.Unix() + ntpEpochOffset)
+	frac := uint64(t.Nanosecond()) << 32 / uint64(time.Second)
+	binary.BigEndian.PutUint64(b, sec<<32|frac)
+}
+
+// ntpTime 读取 64 位 NTP 时间戳
+func ntpTime(b []byte) time.Time {
+	v := binary.BigEndian.Uint64(b)
+	sec, frac := int64(v>>32), int64(v&0xffffffff)
+	return time.Unix(sec-ntpEpochOffset, (frac*int64(time.Second)+1<<31)>>32)
+}
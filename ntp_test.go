@@ -0,0 +1,135 @@
+package timex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeNTPServer 是用 FakeClock 模拟网络延迟的 NTPTransport, 服务器时间比本地时间快 offset
+type fakeNTPServer struct {
+	clock  *FakeClock
+	offset time.Duration
+	// delays 依次是每次请求去程和回程的延迟
+	delays [][2]time.Duration
+	// mutate 不为 nil 时在发送前修改第 n 次请求 (从 0 开始) 的响应, 返回 nil 时模拟传输错误
+	mutate func(n int, resp []byte) []byte
+	calls  int
+}
+
+var errFakeTransport = errors.New("fake transport error")
+
+func (s *fakeNTPServer) Exchange(ctx context.Context, server string, req []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	n := s.calls
+	s.calls++
+	var delay [2]time.Duration
+	if n < len(s.delays) {
+		delay = s.delays[n]
+	}
+
+	s.clock.Advance(delay[0])
+	resp := make([]byte, ntpPacketSize)
+	resp[0] = 4<<3 | 4
+	resp[1] = 2
+	copy(resp[24:32], req[40:48])
+	now := s.clock.Now().Add(s.offset)
+	putNTPTime(resp[32:], now)
+	putNTPTime(resp[40:], now)
+	s.clock.Advance(delay[1])
+
+	if s.mutate != nil {
+		if resp = s.mutate(n, resp); resp == nil {
+			return nil, errFakeTransport
+		}
+	}
+	return resp, nil
+}
+
+func TestNTPProbe(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	server := &fakeNTPServer{
+		clock:  clock,
+		offset: 250 * time.Millisecond,
+		// 去程和回程不对称时估计的偏差有误差, 往返时长最短的第二个样本最准确
+		delays: [][2]time.Duration{{10 * time.Millisecond, 30 * time.Millisecond}, {5 * time.Millisecond, 5 * time.Millisecond}, {50 * time.Millisecond, 10 * time.Millisecond}},
+	}
+	report, err := NewNTPProbe(server, clock).Probe(context.Background(), "pool.ntp.org", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := SkewReport{Server: "pool.ntp.org", Offset: 250 * time.Millisecond, RoundTrip: 10 * time.Millisecond, Dispersion: 20 * time.Millisecond, Stratum: 2, Samples: 3}
+	if report != want {
+		t.Errorf("Probe = %+v, want %+v", report, want)
+	}
+
+	corrected := report.Clock(clock)
+	if got := corrected.Now().Sub(clock.Now()); got != 250*time.Millisecond {
+		t.Errorf("corrected clock is %v ahead, want 250ms", got)
+	}
+}
+
+func TestNTPProbeInvalidResponses(t *testing.T) {
+	invalid := []func(resp []byte) []byte{
+		func(resp []byte) []byte { return resp[:ntpPacketSize-1] },
+		// 模式不是服务器
+		func(resp []byte) []byte { resp[0] = 4<<3 | 3; return resp },
+		// 版本为 0
+		func(resp []byte) []byte { resp[0] = 4; return resp },
+		// 层级为 0 的拒绝服务响应
+		func(resp []byte) []byte { resp[1] = 0; return resp },
+		// 原始时间戳与请求不对应
+		func(resp []byte) []byte { resp[31]++; return resp },
+		func(resp []byte) []byte { return nil },
+	}
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	for i, mutate := range invalid {
+		// 只有最后一个样本有效
+		server := &fakeNTPServer{clock: clock, offset: -time.Second, mutate: func(n int, resp []byte) []byte {
+			if n == 0 {
+				return mutate(resp)
+			}
+			return resp
+		}}
+		report, err := NewNTPProbe(server, clock).Probe(context.Background(), "ntp", 2)
+		if err != nil || report.Samples != 1 || report.Offset != -time.Second {
+			t.Errorf("case %d: Probe = %+v, %v, want 1 sample with offset -1s", i, report, err)
+		}
+
+		server = &fakeNTPServer{clock: clock, mutate: func(n int, resp []byte) []byte { return mutate(resp) }}
+		wantErr := ErrInvalidNTPResponse
+		if i == len(invalid)-1 {
+			wantErr = errFakeTransport
+		}
+		if _, err := NewNTPProbe(server, clock).Probe(context.Background(), "ntp", 2); !errors.Is(err, wantErr) {
+			t.Errorf("case %d: Probe error = %v, want %v", i, err, wantErr)
+		}
+	}
+}
+
+func TestNTPProbeCanceled(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewNTPProbe(&fakeNTPServer{clock: clock}, clock).Probe(ctx, "ntp", 3); !errors.Is(err, context.Canceled) {
+		t.Errorf("Probe error = %v, want context.Canceled", err)
+	}
+}
+
+func TestNTPTimestamp(t *testing.T) {
+	for _, at := range []time.Time{
+		time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 1, time.UTC),
+		time.Date(2024, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		time.Date(2036, 2, 7, 6, 28, 15, 123456789, time.UTC),
+	} {
+		b := make([]byte, 8)
+		putNTPTime(b, at)
+		if got := ntpTime(b); !got.Equal(at) {
+			t.Errorf("ntpTime(putNTPTime(%v)) = %v", at, got)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package timex
+
+import (
+	"sort"
+	"time"
+)
+
+// OccupancyAt 返回 t 时刻同时在场的人数, 即包含 t 的停留时间段个数
+func OccupancyAt(stays []*TimeRange, t time.Time) int {
+	n := 0
+	for _, s := range stays {
+		if s.Contains(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// OccupancySeries 从 tr 的开始时间起每隔 step 采样一次在场人数, 只包含落在 tr 内的采样点. step 不大于 0 时返回 nil.
+func OccupancySeries(stays []*TimeRange, tr *TimeRange, step time.Duration) []int {
+	if step <= 0 {
+		return nil
+	}
+	pieces := depthProfile(stays)
+	var series []int
+	for t := tr.start; !t.After(tr.end); t = t.Add(step) {
+		if tr.Contains(t) {
+			series = append(series, depthAt(pieces, t))
+		}
+	}
+	return series
+}
+
+// depthAt 返回 depthProfile 的结果中 t 时刻的覆盖深度
+func depthAt(pieces []depthPiece, t time.Time) int {
+	// 找到第一个不早于 t 的端点或者结束时间晚于 t 的开区间
+	i := sort.Search(len(pieces), func(i int) bool {
+		if pieces[i].point {
+			return !pieces[i].start.Before(t)
+		}
+		return pieces[i].end.After(t)
+	})
+	if i == len(pieces) || (pieces[i].point && !pieces[i].start.Equal(t)) {
+		return 0
+	}
+	return pieces[i].depth
+}
@@ -0,0 +1,143 @@
+package timex
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnknownTimeFormat 表示 ParseAny 无法识别的时间格式
+var ErrUnknownTimeFormat = errors.New("unknown time format")
+
+// ParseAny 匹配到 unix 时间戳时返回的格式名称
+const (
+	LayoutUnix      = "unix"
+	LayoutUnixMilli = "unixmilli"
+	LayoutUnixMicro = "unixmicro"
+	LayoutUnixNano  = "unixnano"
+)
+
+// parseAnyLayouts 是 ParseAny 依次尝试的格式, 越靠前优先级越高
+var parseAnyLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	"2006/01/02 15:04:05.999999999",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"2006.01.02 15:04:05",
+	"2006.01.02",
+	"01/02/2006 15:04:05",
+	"01/02/2006",
+	"01/02/06",
+	"02-Jan-06",
+	"20060102150405",
+	"20060102",
+	"060102",
+	"2006年1月2日 15:04:05",
+	"2006年1月2日",
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.RubyDate,
+	time.UnixDate,
+	time.ANSIC,
+}
+
+// ParseAny 依次尝试常见的时间格式解析 s, 返回解析结果和匹配到的格式, 都无法匹配时返回 ErrUnknownTimeFormat.
+// 支持 RFC 3339, "2006-01-02 15:04:05", "2006/01/02", "01/02/2006" (月/日/年), "01/02/06", "02-Jan-06", "20060102", 中文日期, RFC 1123 等格式, 不带时区的格式按照 loc 解析.
+// 纯数字按照位数识别为 unix 时间戳: 不超过 10 位为秒, 13 位为毫秒, 16 位为微秒, 19 位为纳秒, 此时返回的格式为 LayoutUnix 等常量, 结果位于 loc 中;
+// 6 位, 8 位和 14 位的纯数字优先识别为 "060102", "20060102" 和 "20060102150405".
+// 两位数的年份按照 time.Parse 的规则解释, 即 69 至 99 为 19xx, 00 至 68 为 20xx, 需要其他规则时使用 ParseAnyWithOptions.
+func ParseAny(s string, loc *time.Location) (time.Time, string, error) {
+	return ParseAnyWithOptions(s, ParseOptions{Location: loc})
+}
+
+// ParseOptions 描述 ParseAnyWithOptions 的解析规则
+type ParseOptions struct {
+	Location *time.Location // 不带时区的格式使用的时区
+	// TwoDigitYearPivot 大于 0 时, 两位数年份小于它的解释为 20xx, 否则解释为 19xx, 如为 50 时 49 解释为 2049, 50 解释为 1950.
+	// 为 0 时使用 time.Parse 的规则, 相当于 69.
+	TwoDigitYearPivot int
+	// Century 大于 0 时两位数年份总是解释为该世纪中的年份, 如为 19 时 05 解释为 1905, 优先于 TwoDigitYearPivot
+	Century int
+}
+
+// ParseAnyWithOptions 与 ParseAny 相同, 但按照 opts 解释两位数的年份
+func ParseAnyWithOptions(s string, opts ParseOptions) (time.Time, string, error) {
+	loc := opts.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	s = strings.TrimSpace(s)
+	digits := s != "" && strings.IndexFunc(strings.TrimPrefix(s, "-"), func(r rune) bool { return r < '0' || r > '9' }) < 0
+	for _, layout := range parseAnyLayouts {
+		if digits && len(s) != len(layout) {
+			continue
+		}
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err != nil {
+			continue
+		}
+		if strings.Contains(layout, "06") && !strings.Contains(layout, "2006") {
+			if t, err = opts.resolveTwoDigitYear(t); err != nil {
+				continue
+			}
+		}
+		return t, layout, nil
+	}
+	if digits {
+		if t, layout, ok := parseUnixDigits(s, loc); ok {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", ErrUnknownTimeFormat
+}
+
+// resolveTwoDigitYear 按照选项重新解释 time.Parse 从两位数年份得到的年份
+func (opts ParseOptions) resolveTwoDigitYear(t time.Time) (time.Time, error) {
+	yy := t.Year() % 100
+	year := t.Year()
+	switch {
+	case opts.Century > 0:
+		year = opts.Century*100 + yy
+	case opts.TwoDigitYearPivot > 0 && yy < opts.TwoDigitYearPivot:
+		year = 2000 + yy
+	case opts.TwoDigitYearPivot > 0:
+		year = 1900 + yy
+	}
+	if year == t.Year() {
+		return t, nil
+	}
+	if t.Month() == time.February && t.Day() == 29 && !IsLeapYear(year) {
+		return time.Time{}, ErrUnknownTimeFormat
+	}
+	return time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+}
+
+// parseUnixDigits 按照位数将纯数字解析为 unix 时间戳
+func parseUnixDigits(s string, loc *time.Location) (time.Time, string, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	switch len(strings.TrimPrefix(s, "-")) {
+	case 1, 2, 3, 4, 5, 6, 7, 8, 9, 10:
+		return time.Unix(n, 0).In(loc), LayoutUnix, true
+	case 13:
+		return time.UnixMilli(n).In(loc), LayoutUnixMilli, true
+	case 16:
+		return time.UnixMicro(n).In(loc), LayoutUnixMicro, true
+	case 19:
+		return time.Unix(0, n).In(loc), LayoutUnixNano, true
+	default:
+		return time.Time{}, "", false
+	}
+}
@@ -0,0 +1,175 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAnyLayouts(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	// 2024-03-05 是周二
+	full := time.Date(2024, 3, 5, 13, 4, 5, 0, loc)
+	minute := time.Date(2024, 3, 5, 13, 4, 0, 0, loc)
+	day := time.Date(2024, 3, 5, 0, 0, 0, 0, loc)
+	cases := []struct {
+		in     string
+		want   time.Time
+		layout string
+	}{
+		{"2024-03-05T13:04:05.25+08:00", full.Add(250 * time.Millisecond), time.RFC3339Nano},
+		{"2024-03-05T05:04:05Z", full, time.RFC3339Nano},
+		{"2024-03-05T13:04:05", full, "2006-01-02T15:04:05.999999999"},
+		{"2024-03-05 05:04:05Z", full, "2006-01-02 15:04:05.999999999Z07:00"},
+		{"2024-03-05 13:04:05.5 +0800 CST", full.Add(500 * time.Millisecond), "2006-01-02 15:04:05.999999999 -0700 MST"},
+		{"2024-03-05 13:04:05", full, "2006-01-02 15:04:05.999999999"},
+		{"2024-03-05 13:04", minute, "2006-01-02 15:04"},
+		{"2024-03-05", day, time.DateOnly},
+		{"2024/03/05 13:04:05", full, "2006/01/02 15:04:05.999999999"},
+		{"2024/03/05 13:04", minute, "2006/01/02 15:04"},
+		{"2024/03/05", day, "2006/01/02"},
+		{"2024.03.05 13:04:05", full, "2006.01.02 15:04:05"},
+		{"2024.03.05", day, "2006.01.02"},
+		// 斜线分隔且年份在后时按照 月/日/年 解析
+		{"03/05/2024 13:04:05", full, "01/02/2006 15:04:05"},
+		{"03/05/2024", day, "01/02/2006"},
+		{"03/05/24", day, "01/02/06"},
+		{"05-Mar-24", day, "02-Jan-06"},
+		{"20240305130405", full, "20060102150405"},
+		{"20240305", day, "20060102"},
+		{"240305", day, "060102"},
+		{"2024年3月5日 13:04:05", full, "2006年1月2日 15:04:05"},
+		{"2024年3月5日", day, "2006年1月2日"},
+		{"Tue, 05 Mar 2024 13:04:05 +0800", full, time.RFC1123Z},
+		{"Tue, 05 Mar 2024 13:04:05 CST", full, time.RFC1123},
+		{"Tuesday, 05-Mar-24 13:04:05 CST", full, time.RFC850},
+		{"05 Mar 24 13:04 +0800", minute, time.RFC822Z},
+		{"05 Mar 24 13:04 CST", minute, time.RFC822},
+		{"Tue Mar 05 13:04:05 +0800 2024", full, time.RubyDate},
+		{"Tue Mar  5 13:04:05 CST 2024", full, time.UnixDate},
+		{"Tue Mar  5 13:04:05 2024", full, time.ANSIC},
+		{"  2024-03-05\n", day, time.DateOnly},
+	}
+	for _, c := range cases {
+		got, layout, err := ParseAny(c.in, loc)
+		if err != nil {
+			t.Errorf("ParseAny(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) || layout != c.layout {
+			t.Errorf("ParseAny(%q) = %v, %q, want %v, %q", c.in, got, layout, c.want, c.layout)
+		}
+	}
+
+	// 不带时区的格式按照 loc 解析, loc 为 nil 时使用 UTC
+	if got, _, _ := ParseAny("2024-03-05 13:04:05", nil); !got.Equal(time.Date(2024, 3, 5, 13, 4, 5, 0, time.UTC)) {
+		t.Errorf("ParseAny with nil loc = %v", got)
+	}
+}
+
+func TestParseAnyDigits(t *testing.T) {
+	loc := time.FixedZone("CST", 8*3600)
+	cases := []struct {
+		in     string
+		want   time.Time
+		layout string
+	}{
+		// 6 位, 8 位和 14 位优先识别为日期
+		{"240305", time.Date(2024, 3, 5, 0, 0, 0, 0, loc), "060102"},
+		{"20240305", time.Date(2024, 3, 5, 0, 0, 0, 0, loc), "20060102"},
+		{"20240305130405", time.Date(2024, 3, 5, 13, 4, 5, 0, loc), "20060102150405"},
+		// 不是有效日期时按照秒级时间戳解析
+		{"240230", time.Unix(240230, 0), LayoutUnix},
+		{"20240230", time.Unix(20240230, 0), LayoutUnix},
+		{"0", time.Unix(0, 0), LayoutUnix},
+		{"1709615045", time.Unix(1709615045, 0), LayoutUnix},
+		{"1709615045123", time.UnixMilli(1709615045123), LayoutUnixMilli},
+		{"1709615045123456", time.UnixMicro(1709615045123456), LayoutUnixMicro},
+		{"1709615045123456789", time.Unix(0, 1709615045123456789), LayoutUnixNano},
+		// 负数的位数不计 "-", 且不会被识别为日期
+		{"-1", time.Unix(-1, 0), LayoutUnix},
+		{"-240305", time.Unix(-240305, 0), LayoutUnix},
+		{"-20240305", time.Unix(-20240305, 0), LayoutUnix},
+		{"-1709615045", time.Unix(-1709615045, 0), LayoutUnix},
+		{"-1709615045123", time.UnixMilli(-1709615045123), LayoutUnixMilli},
+		{"-1709615045123456", time.UnixMicro(-1709615045123456), LayoutUnixMicro},
+		{"-1709615045123456789", time.Unix(0, -1709615045123456789), LayoutUnixNano},
+	}
+	for _, c := range cases {
+		got, layout, err := ParseAny(c.in, loc)
+		if err != nil {
+			t.Errorf("ParseAny(%q): %v", c.in, err)
+			continue
+		}
+		if !got.Equal(c.want) || layout != c.layout {
+			t.Errorf("ParseAny(%q) = %v, %q, want %v, %q", c.in, got, layout, c.want, c.layout)
+		}
+		if got.Location() != loc {
+			t.Errorf("ParseAny(%q) location = %v, want %v", c.in, got.Location(), loc)
+		}
+	}
+}
+
+func TestParseAnyInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", " ", "-", "--1", "abc", "2024-13-01", "2024-02-30", "03/05", "12345678901", "123456789012",
+		"12345678901234", "123456789012345", "12345678901234567890", "99999999999999999999", "1e9", "+1709615045",
+	} {
+		if got, layout, err := ParseAny(s, time.UTC); !errors.Is(err, ErrUnknownTimeFormat) {
+			t.Errorf("ParseAny(%q) = %v, %q, %v, want ErrUnknownTimeFormat", s, got, layout, err)
+		}
+	}
+}
+
+func TestParseAnyTwoDigitYear(t *testing.T) {
+	cases := []struct {
+		in   string
+		opts ParseOptions
+		want time.Time
+	}{
+		// 默认与 time.Parse 相同, 69 为分界
+		{"03/05/69", ParseOptions{}, time.Date(1969, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/68", ParseOptions{}, time.Date(2068, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/49", ParseOptions{TwoDigitYearPivot: 50}, time.Date(2049, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/50", ParseOptions{TwoDigitYearPivot: 50}, time.Date(1950, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/70", ParseOptions{TwoDigitYearPivot: 80}, time.Date(2070, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/00", ParseOptions{TwoDigitYearPivot: 1}, time.Date(2000, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/01", ParseOptions{TwoDigitYearPivot: 1}, time.Date(1901, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/99", ParseOptions{TwoDigitYearPivot: 100}, time.Date(2099, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"240305", ParseOptions{TwoDigitYearPivot: 20}, time.Date(1924, 3, 5, 0, 0, 0, 0, time.UTC)},
+		// Century 优先于 TwoDigitYearPivot
+		{"05-Mar-24", ParseOptions{Century: 19}, time.Date(1924, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/99", ParseOptions{Century: 21, TwoDigitYearPivot: 50}, time.Date(2199, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"03/05/05", ParseOptions{Century: 20, TwoDigitYearPivot: 1}, time.Date(2005, 3, 5, 0, 0, 0, 0, time.UTC)},
+		// 保留时刻和时区
+		{"05 Mar 24 13:04 +0800", ParseOptions{Century: 19}, time.Date(1924, 3, 5, 5, 4, 0, 0, time.UTC)},
+		// 四位数的年份不受影响
+		{"2024-03-05", ParseOptions{Century: 19}, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"20240305", ParseOptions{TwoDigitYearPivot: 10}, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		// 2 月 29 日移到另一个闰年
+		{"02/29/24", ParseOptions{Century: 19}, time.Date(1924, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"02/29/00", ParseOptions{}, time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"02/29/00", ParseOptions{Century: 24}, time.Date(2400, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		got, _, err := ParseAnyWithOptions(c.in, c.opts)
+		if err != nil {
+			t.Errorf("ParseAnyWithOptions(%q, %+v): %v", c.in, c.opts, err)
+			continue
+		}
+		if !got.Equal(c.want) {
+			t.Errorf("ParseAnyWithOptions(%q, %+v) = %v, want %v", c.in, c.opts, got, c.want)
+		}
+	}
+
+	// 2 月 29 日移到非闰年的世纪年时无效
+	for _, opts := range []ParseOptions{{Century: 19}, {Century: 21}, {Century: 19, TwoDigitYearPivot: 50}} {
+		if got, _, err := ParseAnyWithOptions("02/29/00", opts); !errors.Is(err, ErrUnknownTimeFormat) {
+			t.Errorf("ParseAnyWithOptions(%q, %+v) = %v, %v, want ErrUnknownTimeFormat", "02/29/00", opts, got, err)
+		}
+	}
+	// 纯数字不是有效日期时按照时间戳解析
+	if got, layout, err := ParseAnyWithOptions("000229", ParseOptions{Century: 19}); err != nil || layout != LayoutUnix || !got.Equal(time.Unix(229, 0)) {
+		t.Errorf("ParseAnyWithOptions(%q) = %v, %q, %v, want unix 229", "000229", got, layout, err)
+	}
+}
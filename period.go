@@ -0,0 +1,242 @@
+package timex
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period 表示以日历单位计量的时间段, 如 "1 年 2 个月 3 天 4 小时", 与 time.Duration 不同, 它的实际长度取决于从哪一天开始计算.
+// 各字段可以为负数, 也可以正负混合.
+type Period struct {
+	Years       int
+	Months      int
+	Days        int
+	Hours       int
+	Minutes     int
+	Seconds     int
+	Nanoseconds int
+}
+
+// AddTo 返回 t 加上该时间段之后的时间.
+// 年, 月, 日按照 t 所在时区的日历推算并保持墙上时刻不变, 目标月份没有对应日期时取该月最后一天, 如 01-31 加一个月得到 02-28 或 02-29;
+// 时, 分, 秒部分随后作为实际经过的时长累加. 墙上时刻因夏令时切换不存在时向后顺延跳过的时长.
+func (p Period) AddTo(t time.Time) time.Time {
+	return p.AddToWithPolicy(t, OverflowClamp)
+}
+
+// AddToWithPolicy 与 AddTo 相同, 但目标月份没有对应日期时按照 policy 处理
+func (p Period) AddToWithPolicy(t time.Time, policy OverflowPolicy) time.Time {
+	if p.Years != 0 || p.Months != 0 || p.Days != 0 {
+		d := addPeriodToDate(DateOf(t), p, policy)
+		tod := TimeOfDayOf(t)
+		t = tod.On(d, t.Location())
+	}
+	return t.Add(p.timeDuration())
+}
+
+// AddToDate 返回日期加上该时间段中年, 月, 日部分之后的日期, 时, 分, 秒部分会被忽略
+func (p Period) AddToDate(d Date) Date {
+	return addPeriodToDate(d, p, OverflowClamp)
+}
+
+// PeriodBetween 返回从 a 到 b 的时间段, 年, 月, 日按照 a 所在时区的日历计算, 时, 分, 秒为剩余的实际时长, 使得 a 加上结果 (见 AddTo) 恰好等于 b.
+// b 不早于 a 时结果的各字段均为非负数, 否则均为非正数. 年和月尽可能取大, 其余部分不会达到一个月.
+func PeriodBetween(a, b time.Time) Period {
+	b = b.In(a.Location())
+	sign := 1
+	if b.Before(a) {
+		sign = -1
+	}
+
+	wa, wb := wallClock(a), wallClock(b)
+	months := (wb.Year()-wa.Year())*12 + int(wb.Month()) - int(wa.Month())
+	// 按月推算的结果越过了 b 时向 a 的方向退回一个月. b 早于 a 时同样从 a 向前推算, 因为月末的截断并不对称
+	if wallAddMonths(wa, months).Compare(wb) == sign {
+		months -= sign
+	}
+	mid := DateOf(wallAddMonths(wa, months))
+	days := DateOf(wb).DaysSince(mid)
+	// 时, 分, 秒部分与 AddTo 一致, 按实际经过的时长计算
+	tod := TimeOfDayOf(a)
+	anchor := func(days int) time.Time {
+		// 年, 月, 日都为 0 时 AddTo 直接从 a 累加时长, 不重新解析墙上时刻, 两者在夏令时回拨的重复时段中并不相同
+		if months == 0 && days == 0 {
+			return a
+		}
+		return tod.On(mid.AddDays(days), a.Location())
+	}
+	base := anchor(days)
+	if base.Compare(b) == sign && days != 0 {
+		days -= sign
+		base = anchor(days)
+	}
+	rem := b.Sub(base)
+
+	return Period{
+		Years:       months / 12,
+		Months:      months % 12,
+		Days:        days,
+		Hours:       int(rem / time.Hour),
+		Minutes:     int(rem % time.Hour / time.Minute),
+		Seconds:     int(rem % time.Minute / time.Second),
+		Nanoseconds: int(rem % time.Second),
+	}
+}
+
+// Normalize 将月份超过 12 的部分进位到年, 将纳秒, 秒, 分超出的部分依次进位到秒, 分, 时.
+// 由于一天不一定是 24 小时, 一个月也没有固定的天数, 时不会进位到日, 日也不会进位到月.
+func (p Period) Normalize() Period {
+	months := p.Years*12 + p.Months
+	d := p.timeDuration()
+	return Period{
+		Years:       months / 12,
+		Months:      months % 12,
+		Days:        p.Days,
+		Hours:       int(d / time.Hour),
+		Minutes:     int(d % time.Hour / time.Minute),
+		Seconds:     int(d % time.Minute / time.Second),
+		Nanoseconds: int(d % time.Second),
+	}
+}
+
+// Negate 返回各字段取反后的时间段
+func (p Period) Negate() Period {
+	return Period{
+		Years:       -p.Years,
+		Months:      -p.Months,
+		Days:        -p.Days,
+		Hours:       -p.Hours,
+		Minutes:     -p.Minutes,
+		Seconds:     -p.Seconds,
+		Nanoseconds: -p.Nanoseconds,
+	}
+}
+
+// IsZero 判断是否所有字段都为 0
+func (p Period) IsZero() bool {
+	return p == Period{}
+}
+
+// String 返回 ISO 8601 格式的文本, 如 "P1Y2M3DT4H5M6S", 零值返回 "P0D"
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+	var sb strings.Builder
+	sb.WriteByte('P')
+	for _, f := range []struct {
+		v    int
+		unit byte
+	}{{p.Years, 'Y'}, {p.Months, 'M'}, {p.Days, 'D'}} {
+		if f.v != 0 {
+			sb.WriteString(strconv.Itoa(f.v))
+			sb.WriteByte(f.unit)
+		}
+	}
+	if p.Hours != 0 || p.Minutes != 0 || p.Seconds != 0 || p.Nanoseconds != 0 {
+		sb.WriteByte('T')
+		if p.Hours != 0 {
+			sb.WriteString(strconv.Itoa(p.Hours) + "H")
+		}
+		if p.Minutes != 0 {
+			sb.WriteString(strconv.Itoa(p.Minutes) + "M")
+		}
+		if p.Seconds != 0 || p.Nanoseconds != 0 {
+			sec := time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+			sb.WriteString(strconv.FormatFloat(sec.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return sb.String()
+}
+
+// timeDuration 返回时, 分, 秒部分对应的时长
+func (p Period) timeDuration() time.Duration {
+	return time.Duration(p.Hours)*time.Hour + time.Duration(p.Minutes)*time.Minute +
+		time.Duration(p.Seconds)*time.Second + time.Duration(p.Nanoseconds)
+}
+
+// wallClock 返回与 t 的墙上时间字段相同的 UTC 时间, 便于按照墙上时间做算术
+func wallClock(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), time.UTC)
+}
+
+// wallAddMonths 返回墙上时间加上 n 个月之后的墙上时间, 目标月份没有对应日期时取该月最后一天
+func wallAddMonths(w time.Time, n int) time.Time {
+	d := addMonthsToDate(DateOf(w), n, OverflowClamp)
+	return time.Date(d.Year, d.Month, d.Day, w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), time.UTC)
+}
+
+// OverflowPolicy 表示按月或按年推算日期时, 目标月份没有对应日期 (如 1 月 31 日加一个月, 2 月 29 日加一年) 的处理方式
+type OverflowPolicy int
+
+const (
+	// OverflowClamp 取目标月份的最后一天, 如 01-31 加一个月得到 02-28 或 02-29, 02-29 加一年得到 02-28
+	OverflowClamp OverflowPolicy = iota
+	// OverflowRoll 将多出的天数顺延到下个月, 与 time.AddDate 的行为一致, 如 01-31 加一个月得到 03-02 或 03-03, 02-29 加一年得到 03-01
+	OverflowRoll
+	// OverflowEndOfMonth 原日期是月末时结果也总是月末, 如 02-28 (非闰年) 加一个月得到 03-31; 原日期不是月末时与 OverflowClamp 相同
+	OverflowEndOfMonth
+)
+
+// AddDays 返回 t 在 loc 中 n 个日历日之后相同墙上时刻的时间, n 可以为负数, 结果位于 loc 中.
+// 与加上 n*24h 不同, 跨越夏令时切换时墙上时刻保持不变. 目标墙上时刻出现两次时取较早的一个;
+// 因切换被跳过而不存在时按照切换前的偏移解析, 即向后顺延跳过的时长, 如跳过 02:00-03:00 时 02:30 变为 03:30.
+func AddDays(t time.Time, n int, loc *time.Location) time.Time {
+	return Period{Days: n}.AddTo(t.In(loc))
+}
+
+// AddMonths 返回 t 加上 n 个月之后的时间, n 可以为负数. 按照 t 所在时区的日历推算并保持墙上时刻不变, 目标月份没有对应日期时按照 policy 处理.
+// 与 time.Time.AddDate 不同, 只有 OverflowRoll 会把多出的天数顺延到下个月.
+func AddMonths(t time.Time, n int, policy OverflowPolicy) time.Time {
+	return Period{Months: n}.AddToWithPolicy(t, policy)
+}
+
+// AddYears 返回 t 加上 n 年之后的时间, n 可以为负数, 02-29 在非闰年按照 policy 处理
+func AddYears(t time.Time, n int, policy OverflowPolicy) time.Time {
+	return Period{Years: n}.AddToWithPolicy(t, policy)
+}
+
+// addPeriodToDate 返回日期加上 p 之后的日期, 先加年和月并按照 policy 处理溢出, 再加天数
+func addPeriodToDate(d Date, p Period, policy OverflowPolicy) Date {
+	return addMonthsToDate(d, p.Years*12+p.Months, policy).AddDays(p.Days)
+}
+
+// addMonthsToDate 返回日期加上 n 个月之后的日期, 并按照 policy 处理溢出
+func addMonthsToDate(d Date, n int, policy OverflowPolicy) Date {
+	year, month := addMonths(d.Year, d.Month, n)
+	switch policy {
+	case OverflowRoll:
+		return NewDate(year, month, d.Day)
+	case OverflowEndOfMonth:
+		if d.Day == DaysInMonth(d.Year, d.Month) {
+			return Date{Year: year, Month: month, Day: DaysInMonth(year, month)}
+		}
+	}
+	return Date{Year: year, Month: month, Day: min(d.Day, DaysInMonth(year, month))}
+}
+
+// AnniversariesOf 返回从 start 开始每隔 every 的周年日中, 当天零点 (按 loc 计算) 落在 within 内的日期, start 本身不计入.
+// 第 n 个周年日总是由 start 直接加上 n 倍的 every 得到, 而不是在上一个周年日的基础上累加, 因此不会因月末截断而逐渐漂移;
+// 目标月份没有对应日期时按照 policy 处理. every 不能让日期向前或保持不变, 否则返回 nil.
+func AnniversariesOf(start Date, every Period, within *TimeRange, loc *time.Location, policy OverflowPolicy) []Date {
+	var dates []Date
+	prev := start
+	for n := 1; ; n++ {
+		d := addPeriodToDate(start, Period{Years: every.Years * n, Months: every.Months * n, Days: every.Days * n}, policy)
+		if !d.After(prev) {
+			return nil
+		}
+		prev = d
+
+		t := startOfLocalDay(d, loc)
+		if within.IsAfterEnd(t) {
+			return dates
+		}
+		if within.Contains(t) {
+			dates = append(dates, d)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodBetweenRoundTrip(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	cases := []struct {
+		a, b time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), "P-1M-1D"},
+		{time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), "P1M3D"},
+		{time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), time.Date(2023, 11, 30, 9, 0, 0, 0, time.UTC), ""},
+		{time.Date(2023, 5, 31, 8, 30, 0, 0, time.UTC), time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 2, 29, 12, 0, 0, 0, shanghai), time.Date(2023, 2, 28, 13, 0, 0, 0, shanghai), ""},
+		{time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), "P0D"},
+	}
+	for _, c := range cases {
+		p := PeriodBetween(c.a, c.b)
+		if got := p.AddTo(c.a); !got.Equal(c.b) {
+			t.Errorf("PeriodBetween(%v, %v) = %v, AddTo gives %v", c.a, c.b, p, got)
+		}
+		if c.want != "" && p.String() != c.want {
+			t.Errorf("PeriodBetween(%v, %v) = %v, want %v", c.a, c.b, p, c.want)
+		}
+	}
+}
+
+func TestPeriodBetweenAcrossDSTFold(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 2026-11-01 01:00-02:00 在 America/New_York 出现两次, 先 EDT 后 EST
+	a := time.Date(2026, 11, 1, 6, 43, 48, 0, time.UTC).In(ny) // 第二个 01:43:48 EST
+	b := time.Date(2026, 11, 1, 19, 7, 2, 0, ny)
+	if p := PeriodBetween(a, b); !p.AddTo(a).Equal(b) {
+		t.Errorf("PeriodBetween(%v, %v) = %v, AddTo gives %v", a, b, p, p.AddTo(a))
+	}
+
+	start := time.Date(2026, 10, 31, 0, 0, 0, 0, ny)
+	var instants []time.Time
+	for tm := start; tm.Before(start.Add(72 * time.Hour)); tm = tm.Add(37*time.Minute + 11*time.Second) {
+		instants = append(instants, tm)
+	}
+	for _, a := range instants {
+		for _, b := range instants {
+			if p := PeriodBetween(a, b); !p.AddTo(a).Equal(b) {
+				t.Fatalf("PeriodBetween(%v, %v) = %v, AddTo gives %v", a, b, p, p.AddTo(a))
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package timex
+
+import "time"
+
+// TruncateToMilli 将时间截断到毫秒, 便于与只有毫秒精度的系统 (如 JavaScript, Java) 交换时间后仍能直接比较
+func TruncateToMilli(t time.Time) time.Time {
+	return t.Truncate(time.Millisecond)
+}
+
+// TruncateToMicro 将时间截断到微秒, 与 Postgres 等只有微秒精度的系统一致
+func TruncateToMicro(t time.Time) time.Time {
+	return t.Truncate(time.Microsecond)
+}
+
+// UnixMilliRange 创建开始和结束时间分别为 unix 毫秒时间戳 start 和 end 的左闭右开时间范围, 结果位于 UTC. start 不早于 end 时返回 ErrInvalidTimeRange.
+func UnixMilliRange(start, end int64) (*TimeRange, error) {
+	return NewTimeRange(time.UnixMilli(start).UTC(), time.UnixMilli(end).UTC(), true, false)
+}
+
+// UnixMicroRange 创建开始和结束时间分别为 unix 微秒时间戳 start 和 end 的左闭右开时间范围, 结果位于 UTC. start 不早于 end 时返回 ErrInvalidTimeRange.
+func UnixMicroRange(start, end int64) (*TimeRange, error) {
+	return NewTimeRange(time.UnixMicro(start).UTC(), time.UnixMicro(end).UTC(), true, false)
+}
+
+// UnixMilli 返回开始和结束时间的 unix 毫秒时间戳, 不足一毫秒的部分被舍去
+func (tr *TimeRange) UnixMilli() (start, end int64) {
+	return tr.start.UnixMilli(), tr.end.UnixMilli()
+}
+
+// UnixMicro 返回开始和结束时间的 unix 微秒时间戳, 不足一微秒的部分被舍去
+func (tr *TimeRange) UnixMicro() (start, end int64) {
+	return tr.start.UnixMicro(), tr.end.UnixMicro()
+}
+
+// TruncateToMilli 返回开始和结束时间都截断到毫秒的时间范围, 边界的开闭性不变.
+// 两端落在同一毫秒内时截断后的范围可能不再有效 (如 [a, b) 变为 [x, x)), 此时返回 ErrInvalidTimeRange.
+func (tr *TimeRange) TruncateToMilli() (*TimeRange, error) {
+	return NewTimeRange(TruncateToMilli(tr.start), TruncateToMilli(tr.end), tr.startInclusive, tr.endInclusive)
+}
+
+// TruncateToMicro 与 TruncateToMilli 相同, 但截断到微秒
+func (tr *TimeRange) TruncateToMicro() (*TimeRange, error) {
+	return NewTimeRange(TruncateToMicro(tr.start), TruncateToMicro(tr.end), tr.startInclusive, tr.endInclusive)
+}
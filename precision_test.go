@@ -0,0 +1,45 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUnixMilliRange(t *testing.T) {
+	tr, err := UnixMilliRange(1000, 2500)
+	if err != nil {
+		t.Fatalf("UnixMilliRange: %v", err)
+	}
+	if start, end := tr.UnixMilli(); start != 1000 || end != 2500 {
+		t.Errorf("UnixMilli() = %d, %d", start, end)
+	}
+	for _, c := range [][2]int64{{5, 3}, {5, 5}} {
+		if _, err := UnixMilliRange(c[0], c[1]); !errors.Is(err, ErrInvalidTimeRange) {
+			t.Errorf("UnixMilliRange(%d, %d) error = %v, want ErrInvalidTimeRange", c[0], c[1], err)
+		}
+		if _, err := UnixMicroRange(c[0], c[1]); !errors.Is(err, ErrInvalidTimeRange) {
+			t.Errorf("UnixMicroRange(%d, %d) error = %v, want ErrInvalidTimeRange", c[0], c[1], err)
+		}
+	}
+}
+
+func TestTimeRangeTruncateToMilli(t *testing.T) {
+	base := time.UnixMilli(1000).UTC()
+	tr := MustNewTimeRange(base.Add(100*time.Microsecond), base.Add(2*time.Millisecond+300*time.Microsecond), true, false)
+	got, err := tr.TruncateToMilli()
+	if err != nil {
+		t.Fatalf("TruncateToMilli: %v", err)
+	}
+	if start, end := got.UnixMilli(); start != 1000 || end != 1002 {
+		t.Errorf("TruncateToMilli = %v", got)
+	}
+
+	narrow := MustNewTimeRange(base.Add(100*time.Microsecond), base.Add(200*time.Microsecond), true, false)
+	if _, err := narrow.TruncateToMilli(); !errors.Is(err, ErrInvalidTimeRange) {
+		t.Errorf("TruncateToMilli of sub-millisecond range error = %v, want ErrInvalidTimeRange", err)
+	}
+	if _, err := narrow.TruncateToMicro(); err != nil {
+		t.Errorf("TruncateToMicro: %v", err)
+	}
+}
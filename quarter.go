@@ -0,0 +1,138 @@
+package timex
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidQuarter 表示无效的季度
+var ErrInvalidQuarter = errors.New("invalid quarter")
+
+// Quarter 表示一个日历季度, 如 2024 年第 3 季度.
+// 文本及 JSON 编码格式为 "2024-Q3".
+type Quarter struct {
+	Year int
+	Q    int // 季度序号, 1 到 4
+}
+
+// NewQuarter 创建Quarter, 超出范围的季度序号会被规范化, 如 2024 年第 5 季度会变为 2025 年第 1 季度
+func NewQuarter(year, q int) Quarter {
+	return Quarter{Year: year, Q: 1}.AddQuarters(q - 1)
+}
+
+// QuarterOf 返回时间在其自身时区中所属的季度
+func QuarterOf(t time.Time) Quarter {
+	return Quarter{Year: t.Year(), Q: (int(t.Month())-1)/3 + 1}
+}
+
+// QuarterOfByTz 返回时间在指定时区中所属的季度
+func QuarterOfByTz(t time.Time, loc *time.Location) Quarter {
+	return QuarterOf(t.In(loc))
+}
+
+// ParseQuarter 解析 "2024-Q3" 格式的季度, 年份必须是 4 位数字
+func ParseQuarter(s string) (Quarter, error) {
+	if len(s) != 7 || s[4:6] != "-Q" || !isASCIIDigits(s[:4]) {
+		return Quarter{}, ErrInvalidQuarter
+	}
+	year, err := strconv.Atoi(s[:4])
+	if err != nil || s[6] < '1' || s[6] > '4' {
+		return Quarter{}, ErrInvalidQuarter
+	}
+	return Quarter{Year: year, Q: int(s[6] - '0')}, nil
+}
+
+// IsValid 判断季度序号是否在 1 到 4 之间
+func (q Quarter) IsValid() bool {
+	return q.Q >= 1 && q.Q <= 4
+}
+
+// Next 返回下一个季度
+func (q Quarter) Next() Quarter {
+	return q.AddQuarters(1)
+}
+
+// Prev 返回上一个季度
+func (q Quarter) Prev() Quarter {
+	return q.AddQuarters(-1)
+}
+
+// AddQuarters 返回 n 个季度之后的季度, n 可以为负数
+func (q Quarter) AddQuarters(n int) Quarter {
+	ym := q.FirstMonth().AddMonths(3 * n)
+	return Quarter{Year: ym.Year, Q: (int(ym.Month)-1)/3 + 1}
+}
+
+// Compare 比较两个季度, q 早于 other 时返回 -1, 晚于时返回 1, 相同时返回 0
+func (q Quarter) Compare(other Quarter) int {
+	if q.Year != other.Year {
+		return cmp.Compare(q.Year, other.Year)
+	}
+	return cmp.Compare(q.Q, other.Q)
+}
+
+// Before 判断 q 是否早于 other
+func (q Quarter) Before(other Quarter) bool {
+	return q.Compare(other) < 0
+}
+
+// After 判断 q 是否晚于 other
+func (q Quarter) After(other Quarter) bool {
+	return q.Compare(other) > 0
+}
+
+// FirstMonth 返回该季度的第一个月
+func (q Quarter) FirstMonth() YearMonth {
+	return YearMonth{Year: q.Year, Month: time.Month((q.Q-1)*3 + 1)}
+}
+
+// FirstDay 返回该季度的第一天
+func (q Quarter) FirstDay() Date {
+	return q.FirstMonth().FirstDay()
+}
+
+// LastDay 返回该季度的最后一天
+func (q Quarter) LastDay() Date {
+	return q.FirstMonth().AddMonths(2).LastDay()
+}
+
+// Contains 判断日期是否在该季度内
+func (q Quarter) Contains(d Date) bool {
+	return QuarterOf(d.In(time.UTC)) == q
+}
+
+// ToDateRange 转换为包含该季度每一天的 DateRange
+func (q Quarter) ToDateRange() *DateRange {
+	return &DateRange{start: q.FirstDay(), end: q.LastDay()}
+}
+
+// ToTimeRange 转换为指定时区中从季度第一天零点 (包含) 到下季度第一天零点 (不包含) 的 TimeRange
+func (q Quarter) ToTimeRange(loc *time.Location) *TimeRange {
+	return q.ToDateRange().ToTimeRange(loc)
+}
+
+// String 返回 "2024-Q3" 格式的文本
+func (q Quarter) String() string {
+	return fmt.Sprintf("%04d-Q%d", q.Year, q.Q)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (q Quarter) MarshalText() ([]byte, error) {
+	if !q.IsValid() {
+		return nil, ErrInvalidQuarter
+	}
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (q *Quarter) UnmarshalText(text []byte) error {
+	v, err := ParseQuarter(string(text))
+	if err != nil {
+		return err
+	}
+	*q = v
+	return nil
+}
@@ -0,0 +1,62 @@
+package timex
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseQuarter(t *testing.T) {
+	valid := map[string]Quarter{
+		"2024-Q1": {Year: 2024, Q: 1},
+		"2024-Q4": {Year: 2024, Q: 4},
+		"0024-Q3": {Year: 24, Q: 3},
+	}
+	for s, want := range valid {
+		if got, err := ParseQuarter(s); err != nil || got != want {
+			t.Errorf("ParseQuarter(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"-024-Q3", "+024-Q3", " 024-Q3", "2024-Q0", "2024-Q5", "2024-q3", "2024Q3", "2024-Q33", "２０２４-Q3", ""} {
+		if _, err := ParseQuarter(s); !errors.Is(err, ErrInvalidQuarter) {
+			t.Errorf("ParseQuarter(%q) error = %v, want ErrInvalidQuarter", s, err)
+		}
+	}
+}
+
+func TestQuarterArithmetic(t *testing.T) {
+	if got := NewQuarter(2024, 5); got != (Quarter{Year: 2025, Q: 1}) {
+		t.Errorf("NewQuarter(2024, 5) = %v", got)
+	}
+	if got := NewQuarter(2024, 0); got != (Quarter{Year: 2023, Q: 4}) {
+		t.Errorf("NewQuarter(2024, 0) = %v", got)
+	}
+	q := Quarter{Year: 2024, Q: 1}
+	if q.Prev() != (Quarter{Year: 2023, Q: 4}) || q.Next() != (Quarter{Year: 2024, Q: 2}) || q.AddQuarters(-9) != (Quarter{Year: 2021, Q: 4}) {
+		t.Errorf("Prev, Next, AddQuarters(-9) = %v, %v, %v", q.Prev(), q.Next(), q.AddQuarters(-9))
+	}
+	if q.FirstDay() != (Date{2024, 1, 1}) || q.LastDay() != (Date{2024, 3, 31}) {
+		t.Errorf("FirstDay, LastDay = %v, %v", q.FirstDay(), q.LastDay())
+	}
+	if !q.Contains(Date{2024, 3, 31}) || q.Contains(Date{2024, 4, 1}) {
+		t.Error("Contains is wrong at the quarter boundary")
+	}
+}
+
+func TestQuarterJSON(t *testing.T) {
+	b, err := json.Marshal(Quarter{Year: 2024, Q: 3})
+	if err != nil || string(b) != `"2024-Q3"` {
+		t.Fatalf("Marshal = %s, %v", b, err)
+	}
+	var q Quarter
+	if err := json.Unmarshal(b, &q); err != nil || q != (Quarter{Year: 2024, Q: 3}) {
+		t.Errorf("Unmarshal = %v, %v", q, err)
+	}
+	if _, err := json.Marshal(Quarter{Year: 2024, Q: 5}); !errors.Is(err, ErrInvalidQuarter) {
+		t.Errorf("Marshal of an invalid quarter error = %v, want ErrInvalidQuarter", err)
+	}
+	if err := json.Unmarshal([]byte(`"-024-Q3"`), &q); !errors.Is(err, ErrInvalidQuarter) {
+		t.Errorf("Unmarshal(-024-Q3) error = %v, want ErrInvalidQuarter", err)
+	}
+}
@@ -0,0 +1,112 @@
+package timex
+
+import (
+	"errors"
+	"iter"
+	"time"
+)
+
+// ErrOccurrenceNotFound 表示 Schedule 中不存在指定的触发时刻
+var ErrOccurrenceNotFound = errors.New("occurrence not found")
+
+// RecurrenceOverrides 在一个 Schedule (通常是 Recurrence) 之上记录对单次触发的修改: 取消某一次, 或者把某一次改到其他时间,
+// 对应 iCalendar 中的 EXDATE 和 RECURRENCE-ID. 修改以原始触发时刻为键, 它本身也实现了 Schedule, 给出应用修改之后的触发时刻.
+// 改期后的时刻与其他触发时刻重合时只计一次.
+type RecurrenceOverrides struct {
+	base      Schedule
+	cancelled map[time.Time]bool
+	moved     map[time.Time]time.Time // 原始时刻 -> 改期后的时刻
+}
+
+// NewRecurrenceOverrides 创建一个还没有任何修改的RecurrenceOverrides
+func NewRecurrenceOverrides(base Schedule) *RecurrenceOverrides {
+	return &RecurrenceOverrides{base: base, cancelled: map[time.Time]bool{}, moved: map[time.Time]time.Time{}}
+}
+
+// Cancel 取消原始时刻为 at 的那一次触发, 覆盖之前对它的改期. at 不是 base 的触发时刻时返回 ErrOccurrenceNotFound.
+func (o *RecurrenceOverrides) Cancel(at time.Time) error {
+	if !o.isBaseOccurrence(at) {
+		return ErrOccurrenceNotFound
+	}
+	key := at.UTC()
+	delete(o.moved, key)
+	o.cancelled[key] = true
+	return nil
+}
+
+// Move 将原始时刻为 at 的那一次触发改到 to, 覆盖之前对它的取消或改期. at 不是 base 的触发时刻时返回 ErrOccurrenceNotFound.
+func (o *RecurrenceOverrides) Move(at, to time.Time) error {
+	if !o.isBaseOccurrence(at) {
+		return ErrOccurrenceNotFound
+	}
+	key := at.UTC()
+	delete(o.cancelled, key)
+	o.moved[key] = to
+	return nil
+}
+
+// Restore 撤销对原始时刻为 at 的那一次触发的修改, 返回之前是否存在修改
+func (o *RecurrenceOverrides) Restore(at time.Time) bool {
+	key := at.UTC()
+	_, moved := o.moved[key]
+	cancelled := o.cancelled[key]
+	delete(o.moved, key)
+	delete(o.cancelled, key)
+	return moved || cancelled
+}
+
+// IsCancelled 判断原始时刻为 at 的那一次触发是否已被取消
+func (o *RecurrenceOverrides) IsCancelled(at time.Time) bool {
+	return o.cancelled[at.UTC()]
+}
+
+// MovedTo 返回原始时刻为 at 的那一次触发改期后的时刻, 没有改期时返回 false
+func (o *RecurrenceOverrides) MovedTo(at time.Time) (time.Time, bool) {
+	to, ok := o.moved[at.UTC()]
+	return to, ok
+}
+
+// Next 返回应用修改之后晚于 after 的第一个触发时刻, 实现了 Schedule
+func (o *RecurrenceOverrides) Next(after time.Time) (time.Time, bool) {
+	next, found := time.Time{}, false
+	for t := after; ; {
+		bt, ok := o.base.Next(t)
+		if !ok {
+			break
+		}
+		key := bt.UTC()
+		if _, moved := o.moved[key]; !moved && !o.cancelled[key] {
+			next, found = bt, true
+			break
+		}
+		t = bt
+	}
+	for _, to := range o.moved {
+		if to.After(after) && (!found || to.Before(next)) {
+			next, found = to, true
+		}
+	}
+	return next, found
+}
+
+// OccurrencesIn 依次迭代应用修改之后落在时间范围内的触发时刻, 原始时刻在范围外但改期到范围内的触发也会包含在内
+func (o *RecurrenceOverrides) OccurrencesIn(tr *TimeRange) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for t := tr.start.Add(-time.Nanosecond); ; {
+			next, ok := o.Next(t)
+			if !ok || tr.IsAfterEnd(next) {
+				return
+			}
+			if tr.Contains(next) && !yield(next) {
+				return
+			}
+			t = next
+		}
+	}
+}
+
+// isBaseOccurrence 判断 at 是否为 base 的触发时刻
+func (o *RecurrenceOverrides) isBaseOccurrence(at time.Time) bool {
+	t, ok := o.base.Next(at.Add(-time.Nanosecond))
+	return ok && t.Equal(at)
+}
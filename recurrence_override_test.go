@@ -0,0 +1,76 @@
+package timex
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"testing"
+	"time"
+)
+
+// collectTimes 将迭代器中的时间收集为切片
+func collectTimes(seq iter.Seq[time.Time]) []time.Time {
+	var ts []time.Time
+	seq(func(t time.Time) bool {
+		ts = append(ts, t)
+		return true
+	})
+	return ts
+}
+
+func TestRecurrenceOverrides(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return start.AddDate(0, 0, d-1) }
+	o := NewRecurrenceOverrides(MustNewRecurrence(start, RRule{Freq: FreqDaily}))
+
+	if err := o.Cancel(day(2)); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if err := o.Move(day(3), day(5).Add(3*time.Hour)); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	// 范围外的一次改期到范围内
+	if err := o.Move(day(10), day(4).Add(time.Hour)); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if err := o.Cancel(day(2).Add(time.Minute)); !errors.Is(err, ErrOccurrenceNotFound) {
+		t.Fatalf("Cancel of non-occurrence: got %v, want ErrOccurrenceNotFound", err)
+	}
+
+	tr := MustNewTimeRange(day(1), day(6), true, false)
+	got := collectTimes(o.OccurrencesIn(tr))
+	want := []time.Time{day(1), day(4), day(4).Add(time.Hour), day(5), day(5).Add(3 * time.Hour)}
+	if !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Fatalf("OccurrencesIn = %v, want %v", got, want)
+	}
+
+	if !o.IsCancelled(day(2)) {
+		t.Error("IsCancelled(day 2) = false, want true")
+	}
+	if to, ok := o.MovedTo(day(3)); !ok || !to.Equal(day(5).Add(3*time.Hour)) {
+		t.Errorf("MovedTo(day 3) = %v, %v", to, ok)
+	}
+	if !o.Restore(day(2)) || o.Restore(day(2)) {
+		t.Error("Restore should report an existing override only once")
+	}
+	if next, ok := o.Next(day(1)); !ok || !next.Equal(day(2)) {
+		t.Errorf("Next after restore = %v, %v, want %v", next, ok, day(2))
+	}
+}
+
+func TestRecurrenceOverridesFiniteBase(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	o := NewRecurrenceOverrides(MustNewRecurrence(start, RRule{Freq: FreqDaily, Count: 2}))
+	if err := o.Cancel(start.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	if _, ok := o.Next(start); ok {
+		t.Error("Next after the last occurrence was cancelled should report false")
+	}
+	if err := o.Move(start, start.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if next, ok := o.Next(start.Add(-time.Hour)); !ok || !next.Equal(start.AddDate(0, 0, 7)) {
+		t.Errorf("Next = %v, %v, want moved occurrence", next, ok)
+	}
+}
@@ -0,0 +1,91 @@
+package timex
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// RelativeFormat 描述 RelativeTime 的阈值
+type RelativeFormat struct {
+	JustNow       time.Duration // 相差不足该时长时输出 "just now" / "刚刚", 为 0 时按一分钟处理
+	AbsoluteAfter time.Duration // 相差超过该时长时改为输出绝对日期, 如 "Jan 2, 2006" / "2006年1月2日", 为 0 时总是输出相对时间
+}
+
+var relativeUnits = []struct {
+	d          time.Duration
+	en, plural string
+	zh         string
+}{
+	{365 * 24 * time.Hour, "year", "years", "年"},
+	{30 * 24 * time.Hour, "month", "months", "个月"},
+	{24 * time.Hour, "day", "days", "天"},
+	{time.Hour, "hour", "hours", "小时"},
+	{time.Minute, "minute", "minutes", "分钟"},
+	{time.Second, "second", "seconds", "秒"},
+}
+
+// RelativeTime 返回 t 相对于 now 的描述, 如 "3 hours ago", "in 2 days", "3小时前", "2天后", 使用默认的阈值
+func RelativeTime(t, now time.Time, locale Locale) string {
+	return RelativeFormat{}.Format(t, now, locale)
+}
+
+// Format 返回 t 相对于 now 的描述. 相对时间取相差时长所能表示的最大单位并向下取整, 一个月按 30 天计算, 一年按 365 天计算;
+// 绝对日期按照 now 所在的时区输出.
+func (f RelativeFormat) Format(t, now time.Time, locale Locale) string {
+	justNow := f.JustNow
+	if justNow == 0 {
+		justNow = time.Minute
+	}
+	d := t.Sub(now)
+	future := d > 0
+	if d < 0 {
+		// 相差过大时 t.Sub 饱和为 math.MinInt64, 直接取反会溢出
+		d = -max(d, -math.MaxInt64)
+	}
+
+	if f.AbsoluteAfter > 0 && d > f.AbsoluteAfter {
+		if locale.isChinese() {
+			return t.In(now.Location()).Format("2006年1月2日")
+		}
+		return t.In(now.Location()).Format("Jan 2, 2006")
+	}
+	if d < justNow {
+		if locale.isChinese() {
+			return "刚刚"
+		}
+		return "just now"
+	}
+
+	phrase := relativeAmount(d, locale)
+	switch {
+	case locale.isChinese() && future:
+		return phrase + "后"
+	case locale.isChinese():
+		return phrase + "前"
+	case future:
+		return "in " + phrase
+	default:
+		return phrase + " ago"
+	}
+}
+
+// relativeAmount 用能表示 d 的最大单位描述 d, 向下取整, 如 "3 hours" / "3小时"
+func relativeAmount(d time.Duration, locale Locale) string {
+	u := relativeUnits[len(relativeUnits)-1]
+	for _, unit := range relativeUnits {
+		if d >= unit.d {
+			u = unit
+			break
+		}
+	}
+	n := int64(d / u.d)
+	switch {
+	case locale.isChinese():
+		return strconv.FormatInt(n, 10) + u.zh
+	case n == 1:
+		return "1 " + u.en
+	default:
+		return strconv.FormatInt(n, 10) + " " + u.plural
+	}
+}
@@ -0,0 +1,80 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	cases := []struct {
+		offset time.Duration
+		en, zh string
+	}{
+		{0, "just now", "刚刚"},
+		{-59 * time.Second, "just now", "刚刚"},
+		{59 * time.Second, "just now", "刚刚"},
+		{-time.Minute, "1 minute ago", "1分钟前"},
+		{90 * time.Second, "in 1 minute", "1分钟后"},
+		{-3*time.Hour - 59*time.Minute, "3 hours ago", "3小时前"},
+		{2*day + time.Hour, "in 2 days", "2天后"},
+		{-29 * day, "29 days ago", "29天前"},
+		// 一个月按 30 天, 一年按 365 天计算
+		{-30 * day, "1 month ago", "1个月前"},
+		{-364 * day, "12 months ago", "12个月前"},
+		{365 * day, "in 1 year", "1年后"},
+		{-800 * day, "2 years ago", "2年前"},
+	}
+	for _, c := range cases {
+		if got := RelativeTime(now.Add(c.offset), now, LocaleEnglish); got != c.en {
+			t.Errorf("RelativeTime(%v) = %q, want %q", c.offset, got, c.en)
+		}
+		if got := RelativeTime(now.Add(c.offset), now, LocaleChinese); got != c.zh {
+			t.Errorf("RelativeTime(%v, zh-CN) = %q, want %q", c.offset, got, c.zh)
+		}
+	}
+
+	// 不支持的语言按英文输出
+	if got := RelativeTime(now.Add(-2*time.Hour), now, Locale("fr")); got != "2 hours ago" {
+		t.Errorf("RelativeTime(fr) = %q", got)
+	}
+}
+
+func TestRelativeFormat(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.FixedZone("CST", 8*3600))
+	f := RelativeFormat{JustNow: 10 * time.Second, AbsoluteAfter: 7 * 24 * time.Hour}
+	cases := []struct {
+		t      time.Time
+		en, zh string
+	}{
+		{now.Add(-5 * time.Second), "just now", "刚刚"},
+		{now.Add(-15 * time.Second), "15 seconds ago", "15秒前"},
+		{now.Add(7 * 24 * time.Hour), "in 7 days", "7天后"},
+		// 绝对日期按照 now 所在的时区输出
+		{time.Date(2024, 2, 26, 20, 0, 0, 0, time.UTC), "Feb 27, 2024", "2024年2月27日"},
+		{now.Add(8 * 24 * time.Hour), "Mar 13, 2024", "2024年3月13日"},
+	}
+	for _, c := range cases {
+		if got := f.Format(c.t, now, LocaleEnglish); got != c.en {
+			t.Errorf("Format(%v) = %q, want %q", c.t, got, c.en)
+		}
+		if got := f.Format(c.t, now, LocaleChinese); got != c.zh {
+			t.Errorf("Format(%v, zh-CN) = %q, want %q", c.t, got, c.zh)
+		}
+	}
+}
+
+func TestRelativeTimeSaturated(t *testing.T) {
+	now := time.Date(2024, 3, 5, 12, 0, 0, 0, time.UTC)
+	// 相差超过 time.Duration 的范围时 t.Sub 饱和, 不能被当作 "刚刚"
+	if got := RelativeTime(time.Time{}, now, LocaleEnglish); got != "292 years ago" {
+		t.Errorf("RelativeTime(zero) = %q, want %q", got, "292 years ago")
+	}
+	if got := RelativeTime(time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), now, LocaleChinese); got != "292年后" {
+		t.Errorf("RelativeTime(9999) = %q, want %q", got, "292年后")
+	}
+	if got := (RelativeFormat{AbsoluteAfter: time.Hour}).Format(time.Time{}, now, LocaleEnglish); got != "Jan 1, 0001" {
+		t.Errorf("Format(zero) = %q, want %q", got, "Jan 1, 0001")
+	}
+}
@@ -0,0 +1,32 @@
+package timex
+
+import "time"
+
+// Reliability 根据故障时间段计算观察期内的平均修复时间 (MTTR), 平均故障间隔 (MTBF) 和可用率.
+// 故障先被裁剪到观察期内, 重叠或首尾相接的故障合并为一次故障; 跨越观察期边界的故障计入故障次数, 但只有观察期内的部分计入故障时长.
+// MTTR 为故障总时长除以故障次数, MTBF 为正常运行总时长除以故障次数, 没有故障时 MTTR 为 0, MTBF 为整个观察期的时长.
+// 观察期时长为 0 时可用率为 1.
+func Reliability(outages []*TimeRange, observation *TimeRange) (mttr, mtbf time.Duration, availability float64) {
+	var clipped []*TimeRange
+	for _, o := range outages {
+		if s, e, ok := overlapBounds(o, observation); ok {
+			clipped = append(clipped, &TimeRange{start: s, end: e, startInclusive: true})
+		}
+	}
+
+	var down time.Duration
+	merged := QuorumWindow(clipped, 1)
+	for _, o := range merged {
+		down += o.end.Sub(o.start)
+	}
+	total := observation.end.Sub(observation.start)
+	up := total - down
+	if total <= 0 {
+		return 0, 0, 1
+	}
+	if len(merged) == 0 {
+		return 0, total, 1
+	}
+	n := time.Duration(len(merged))
+	return down / n, up / n, float64(up) / float64(total)
+}
@@ -0,0 +1,110 @@
+package timex
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// ViolationKind 表示工时规则违规的类型
+type ViolationKind int
+
+const (
+	// ViolationInsufficientRest 表示两段工作之间的休息时间不足
+	ViolationInsufficientRest ViolationKind = iota + 1
+	// ViolationMaxConsecutive 表示连续工作时长超过上限
+	ViolationMaxConsecutive
+)
+
+// String 返回违规类型的名称
+func (k ViolationKind) String() string {
+	switch k {
+	case ViolationInsufficientRest:
+		return "insufficient rest"
+	case ViolationMaxConsecutive:
+		return "max consecutive"
+	default:
+		return "unknown"
+	}
+}
+
+// Violation 表示一次工时规则违规
+type Violation struct {
+	Kind ViolationKind
+	// Range 对于 ViolationInsufficientRest 是不足的休息时间段, 对于 ViolationMaxConsecutive 是超长的连续工作时间段
+	Range *TimeRange
+}
+
+// CheckRestPeriods 检查工作时间段是否满足最短休息时间和最长连续工作时长的要求, 返回按时间排序的所有违规.
+// 首尾相接或重叠的工作时间段视为一段连续工作, minRest 或 maxConsecutive 不大于 0 时不检查对应的规则.
+func CheckRestPeriods(worked []*TimeRange, minRest time.Duration, maxConsecutive time.Duration) []Violation {
+	var violations []Violation
+	stretches := QuorumWindow(worked, 1)
+	for i, s := range stretches {
+		if minRest > 0 && i > 0 {
+			prev := stretches[i-1]
+			if s.start.Sub(prev.end) < minRest {
+				gap := &TimeRange{start: prev.end, end: s.start, startInclusive: !prev.endInclusive, endInclusive: !s.startInclusive}
+				violations = append(violations, Violation{Kind: ViolationInsufficientRest, Range: gap})
+			}
+		}
+		if maxConsecutive > 0 && s.end.Sub(s.start) > maxConsecutive {
+			violations = append(violations, Violation{Kind: ViolationMaxConsecutive, Range: s})
+		}
+	}
+	return violations
+}
+
+// RollingHoursExceeded 返回累计工作时长超过 limit 的滑动窗口, 如任意连续 7 天内工作超过 60 小时.
+// 对于任意开始时间 s, 窗口 [s, s+window) 内的工作时长超过 limit 时, 该窗口会被计入结果, 结果是所有这类窗口的并集, 按时间排序且互不重叠.
+// 重叠的工作时间段只计算一次, window 不大于 0 时返回 nil.
+func RollingHoursExceeded(worked []*TimeRange, window time.Duration, limit time.Duration) []*TimeRange {
+	union := QuorumWindow(worked, 1)
+	if window <= 0 || len(union) == 0 {
+		return nil
+	}
+
+	prefix := make([]time.Duration, len(union)+1)
+	for i, r := range union {
+		prefix[i+1] = prefix[i] + r.end.Sub(r.start)
+	}
+	// covered 返回 t 之前的累计工作时长
+	covered := func(t time.Time) time.Duration {
+		i := sort.Search(len(union), func(i int) bool { return union[i].end.After(t) })
+		d := prefix[i]
+		if i < len(union) && t.After(union[i].start) {
+			d += t.Sub(union[i].start)
+		}
+		return d
+	}
+	inWindow := func(s time.Time) time.Duration {
+		return covered(s.Add(window)) - covered(s)
+	}
+
+	// 窗口内的工作时长是开始时间的分段线性函数, 斜率只在窗口的某一端经过工作时间段的端点时改变
+	bounds := make([]time.Time, 0, len(union)*4)
+	for _, r := range union {
+		bounds = append(bounds, r.start, r.end, r.start.Add(-window), r.end.Add(-window))
+	}
+	slices.SortFunc(bounds, func(a, b time.Time) int { return a.Compare(b) })
+	bounds = slices.CompactFunc(bounds, func(a, b time.Time) bool { return a.Equal(b) })
+
+	var windows []*TimeRange
+	for i := 0; i+1 < len(bounds); i++ {
+		a, b := bounds[i], bounds[i+1]
+		fa, fb := inWindow(a), inWindow(b)
+		var s, e time.Time
+		switch {
+		case fa > limit && fb > limit:
+			s, e = a, b
+		case fa > limit:
+			s, e = a, a.Add(fa-limit)
+		case fb > limit:
+			s, e = a.Add(limit-fa), b
+		default:
+			continue
+		}
+		windows = append(windows, &TimeRange{start: s, end: e.Add(window), startInclusive: true})
+	}
+	return QuorumWindow(windows, 1)
+}
@@ -0,0 +1,137 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidRetailCalendar 表示无效的零售日历配置
+var ErrInvalidRetailCalendar = errors.New("invalid retail calendar")
+
+// RetailPattern 表示零售日历中每个季度三个期间的周数
+type RetailPattern int
+
+const (
+	Pattern445 RetailPattern = iota + 1 // 4-4-5
+	Pattern454                          // 4-5-4
+	Pattern544                          // 5-4-4
+)
+
+// weeks 返回一个季度中三个期间各自的周数
+func (p RetailPattern) weeks() [3]int {
+	switch p {
+	case Pattern454:
+		return [3]int{4, 5, 4}
+	case Pattern544:
+		return [3]int{5, 4, 4}
+	default:
+		return [3]int{4, 4, 5}
+	}
+}
+
+// RetailYearEnd 表示零售日历财年结束日的确定规则
+type RetailYearEnd int
+
+const (
+	// YearEndLastWeekday 财年结束于结束月份的最后一个指定星期
+	YearEndLastWeekday RetailYearEnd = iota + 1
+	// YearEndNearestWeekday 财年结束于离结束月份最后一天最近的指定星期, 可能落在下个月初, 如美国零售联合会的 4-5-4 日历结束于离 1 月 31 日最近的周六
+	YearEndNearestWeekday
+)
+
+// RetailWeek 表示日期在零售日历中所属的周
+type RetailWeek struct {
+	Year    int // 财年
+	Quarter int // 季度, 1 到 4
+	Period  int // 期间, 1 到 12
+	Week    int // 在财年中的第几周, 1 到 52 或 53
+}
+
+// RetailCalendar 表示 4-4-5, 4-5-4 或 5-4-4 零售日历, 每个财年由 52 或 53 个完整的周组成, 分为 4 个季度 12 个期间.
+// 财年以其结束日所在的公历年命名 (结束日因就近规则落在下一年初时仍以结束月份所在的年命名); 有 53 周的财年, 多出的一周计入最后一个期间.
+type RetailCalendar struct {
+	pattern    RetailPattern
+	endMonth   time.Month
+	endWeekday time.Weekday
+	rule       RetailYearEnd
+}
+
+// MustNewRetailCalendar 创建RetailCalendar, 如果参数无效则 panic
+func MustNewRetailCalendar(pattern RetailPattern, endMonth time.Month, endWeekday time.Weekday, rule RetailYearEnd) *RetailCalendar {
+	c, err := NewRetailCalendar(pattern, endMonth, endWeekday, rule)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// NewRetailCalendar 创建RetailCalendar, 财年结束于 endMonth 月按照 rule 确定的星期 endWeekday
+func NewRetailCalendar(pattern RetailPattern, endMonth time.Month, endWeekday time.Weekday, rule RetailYearEnd) (*RetailCalendar, error) {
+	if pattern < Pattern445 || pattern > Pattern544 || endMonth < time.January || endMonth > time.December ||
+		endWeekday < time.Sunday || endWeekday > time.Saturday || rule < YearEndLastWeekday || rule > YearEndNearestWeekday {
+		return nil, ErrInvalidRetailCalendar
+	}
+	return &RetailCalendar{pattern: pattern, endMonth: endMonth, endWeekday: endWeekday, rule: rule}, nil
+}
+
+// YearEnd 返回财年 year 的最后一天
+func (c *RetailCalendar) YearEnd(year int) Date {
+	last := lastWeekdayDate(year, c.endMonth, c.endWeekday)
+	if c.rule == YearEndNearestWeekday && NewDate(year, c.endMonth+1, 0).DaysSince(last) > 3 {
+		last = last.AddDays(7)
+	}
+	return last
+}
+
+// YearDateRange 返回财年 year 的第一天至最后一天
+func (c *RetailCalendar) YearDateRange(year int) *DateRange {
+	return &DateRange{start: c.YearEnd(year - 1).AddDays(1), end: c.YearEnd(year)}
+}
+
+// Weeks 返回财年 year 包含的周数, 52 或 53
+func (c *RetailCalendar) Weeks(year int) int {
+	return c.YearEnd(year).DaysSince(c.YearEnd(year-1)) / 7
+}
+
+// PeriodDateRange 返回财年 year 第 period 个期间 (1 到 12) 的第一天至最后一天, period 超出范围时返回 nil
+func (c *RetailCalendar) PeriodDateRange(year, period int) *DateRange {
+	if period < 1 || period > 12 {
+		return nil
+	}
+	start := c.YearDateRange(year).start
+	weeks := c.pattern.weeks()
+	for p := 1; p < period; p++ {
+		start = start.AddDays(7 * weeks[(p-1)%3])
+	}
+	n := weeks[(period-1)%3]
+	if period == 12 && c.Weeks(year) == 53 {
+		n++
+	}
+	return &DateRange{start: start, end: start.AddDays(7*n - 1)}
+}
+
+// PeriodTimeRange 返回财年 year 第 period 个期间在指定时区中从第一天零点 (包含) 到下一期间第一天零点 (不包含) 的 TimeRange, period 超出范围时返回 nil
+func (c *RetailCalendar) PeriodTimeRange(year, period int, loc *time.Location) *TimeRange {
+	dr := c.PeriodDateRange(year, period)
+	if dr == nil {
+		return nil
+	}
+	return dr.ToTimeRange(loc)
+}
+
+// WeekOf 返回日期在零售日历中所属的财年, 季度, 期间和周
+func (c *RetailCalendar) WeekOf(d Date) RetailWeek {
+	year := d.Year - 1
+	for c.YearEnd(year).Before(d) {
+		year++
+	}
+	week := d.DaysSince(c.YearDateRange(year).start)/7 + 1
+
+	weeks := c.pattern.weeks()
+	period, remaining := 1, week
+	for period < 12 && remaining > weeks[(period-1)%3] {
+		remaining -= weeks[(period-1)%3]
+		period++
+	}
+	return RetailWeek{Year: year, Quarter: (period-1)/3 + 1, Period: period, Week: week}
+}
@@ -0,0 +1,125 @@
+package timex
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// nrf 是美国零售联合会的 4-5-4 日历, 结束于离 1 月 31 日最近的周六
+var nrf = MustNewRetailCalendar(Pattern454, time.January, time.Saturday, YearEndNearestWeekday)
+
+func TestRetailCalendarYearEnd(t *testing.T) {
+	cases := []struct {
+		cal   *RetailCalendar
+		year  int
+		end   Date
+		weeks int
+	}{
+		{nrf, 2022, NewDate(2022, time.January, 29), 52},
+		{nrf, 2023, NewDate(2023, time.January, 28), 52},
+		// 1 月 31 日是周三, 最近的周六在 2 月初, 该财年有 53 周
+		{nrf, 2024, NewDate(2024, time.February, 3), 53},
+		{nrf, 2025, NewDate(2025, time.February, 1), 52},
+		{MustNewRetailCalendar(Pattern445, time.December, time.Saturday, YearEndLastWeekday), 2022, NewDate(2022, time.December, 31), 53},
+		{MustNewRetailCalendar(Pattern445, time.December, time.Saturday, YearEndLastWeekday), 2024, NewDate(2024, time.December, 28), 52},
+	}
+	for _, c := range cases {
+		if got := c.cal.YearEnd(c.year); got != c.end {
+			t.Errorf("YearEnd(%d) = %v, want %v", c.year, got, c.end)
+		}
+		if got := c.cal.Weeks(c.year); got != c.weeks {
+			t.Errorf("Weeks(%d) = %d, want %d", c.year, got, c.weeks)
+		}
+		if dr := c.cal.YearDateRange(c.year); dr.End() != c.end || dr.Days() != 7*c.weeks {
+			t.Errorf("YearDateRange(%d) = %v - %v", c.year, dr.Start(), dr.End())
+		}
+	}
+}
+
+func TestRetailCalendarPeriods(t *testing.T) {
+	cases := []struct {
+		cal        *RetailCalendar
+		year       int
+		period     int
+		start, end Date
+	}{
+		{nrf, 2024, 1, NewDate(2023, time.January, 29), NewDate(2023, time.February, 25)},
+		{nrf, 2024, 2, NewDate(2023, time.February, 26), NewDate(2023, time.April, 1)},
+		{nrf, 2024, 3, NewDate(2023, time.April, 2), NewDate(2023, time.April, 29)},
+		// 53 周的财年多出的一周计入第 12 个期间
+		{nrf, 2024, 12, NewDate(2023, time.December, 31), NewDate(2024, time.February, 3)},
+		{nrf, 2025, 12, NewDate(2025, time.January, 5), NewDate(2025, time.February, 1)},
+		{MustNewRetailCalendar(Pattern445, time.December, time.Saturday, YearEndLastWeekday), 2022, 3, NewDate(2022, time.February, 20), NewDate(2022, time.March, 26)},
+		{MustNewRetailCalendar(Pattern544, time.December, time.Saturday, YearEndLastWeekday), 2022, 1, NewDate(2021, time.December, 26), NewDate(2022, time.January, 29)},
+	}
+	for _, c := range cases {
+		dr := c.cal.PeriodDateRange(c.year, c.period)
+		if dr == nil || dr.Start() != c.start || dr.End() != c.end {
+			t.Errorf("PeriodDateRange(%d, %d) = %v, want %v - %v", c.year, c.period, dr, c.start, c.end)
+		}
+	}
+
+	for _, period := range []int{0, 13} {
+		if dr := nrf.PeriodDateRange(2024, period); dr != nil {
+			t.Errorf("PeriodDateRange(2024, %d) = %v, want nil", period, dr)
+		}
+		if tr := nrf.PeriodTimeRange(2024, period, time.UTC); tr != nil {
+			t.Errorf("PeriodTimeRange(2024, %d) = %v, want nil", period, tr)
+		}
+	}
+	tr := nrf.PeriodTimeRange(2024, 12, time.UTC)
+	want := MustNewTimeRange(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), true, false)
+	if tr.String() != want.String() {
+		t.Errorf("PeriodTimeRange(2024, 12) = %v, want %v", tr, want)
+	}
+}
+
+func TestRetailCalendarWeekOf(t *testing.T) {
+	cases := []struct {
+		date Date
+		want RetailWeek
+	}{
+		{NewDate(2023, time.January, 28), RetailWeek{Year: 2023, Quarter: 4, Period: 12, Week: 52}},
+		{NewDate(2023, time.January, 29), RetailWeek{Year: 2024, Quarter: 1, Period: 1, Week: 1}},
+		{NewDate(2023, time.February, 26), RetailWeek{Year: 2024, Quarter: 1, Period: 2, Week: 5}},
+		{NewDate(2023, time.December, 30), RetailWeek{Year: 2024, Quarter: 4, Period: 11, Week: 48}},
+		// 跨越公历新年仍属于同一财年
+		{NewDate(2023, time.December, 31), RetailWeek{Year: 2024, Quarter: 4, Period: 12, Week: 49}},
+		{NewDate(2024, time.January, 1), RetailWeek{Year: 2024, Quarter: 4, Period: 12, Week: 49}},
+		{NewDate(2024, time.February, 1), RetailWeek{Year: 2024, Quarter: 4, Period: 12, Week: 53}},
+		{NewDate(2024, time.February, 3), RetailWeek{Year: 2024, Quarter: 4, Period: 12, Week: 53}},
+		{NewDate(2024, time.February, 4), RetailWeek{Year: 2025, Quarter: 1, Period: 1, Week: 1}},
+	}
+	for _, c := range cases {
+		if got := nrf.WeekOf(c.date); got != c.want {
+			t.Errorf("WeekOf(%v) = %+v, want %+v", c.date, got, c.want)
+		}
+	}
+
+	lastSat := MustNewRetailCalendar(Pattern445, time.December, time.Saturday, YearEndLastWeekday)
+	if got, want := lastSat.WeekOf(NewDate(2022, time.December, 31)), (RetailWeek{Year: 2022, Quarter: 4, Period: 12, Week: 53}); got != want {
+		t.Errorf("WeekOf(2022-12-31) = %+v, want %+v", got, want)
+	}
+	if got, want := lastSat.WeekOf(NewDate(2023, time.January, 1)), (RetailWeek{Year: 2023, Quarter: 1, Period: 1, Week: 1}); got != want {
+		t.Errorf("WeekOf(2023-01-01) = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewRetailCalendarInvalid(t *testing.T) {
+	for _, args := range []struct {
+		pattern RetailPattern
+		month   time.Month
+		weekday time.Weekday
+		rule    RetailYearEnd
+	}{
+		{0, time.January, time.Saturday, YearEndLastWeekday},
+		{Pattern454, 13, time.Saturday, YearEndLastWeekday},
+		{Pattern454, time.January, 7, YearEndLastWeekday},
+		{Pattern454, time.January, time.Saturday, 0},
+	} {
+		if _, err := NewRetailCalendar(args.pattern, args.month, args.weekday, args.rule); !errors.Is(err, ErrInvalidRetailCalendar) {
+			t.Errorf("NewRetailCalendar(%+v) error = %v, want ErrInvalidRetailCalendar", args, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package timex
+
+import "time"
+
+// RolloutStep 表示渐进发布中的一个阶段
+type RolloutStep struct {
+	Fraction float64   // 该阶段的发布比例
+	Start    time.Time // 该阶段的开始时间
+}
+
+// RolloutSteps 从 start 开始为每个发布比例安排一个阶段, 相邻阶段的开始时间间隔 stepDuration.
+// 开始时间落在暂停窗口 (如封网时段) 内的阶段会被推迟到窗口结束, 之后的阶段以推迟后的时间为基准继续计算.
+func RolloutSteps(start time.Time, steps []float64, stepDuration time.Duration, pauseWindows TimeRangeSet) []RolloutStep {
+	result := make([]RolloutStep, 0, len(steps))
+	t := start
+	for i, fraction := range steps {
+		if i > 0 {
+			t = t.Add(stepDuration)
+		}
+		t = pauseWindows.nextOutside(t)
+		result = append(result, RolloutStep{Fraction: fraction, Start: t})
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidRotation 表示无效的轮值配置
+var ErrInvalidRotation = errors.New("invalid rotation")
+
+// Rotation 表示值班轮换: 参与者按顺序轮流值班, 每班持续若干天, 在指定时区的固定时刻交接.
+// 班次长度按日历天数计算, 因此夏令时切换前后交接的墙上时刻保持不变.
+type Rotation struct {
+	participants []string
+	anchor       Date
+	shiftDays    int
+	handoff      TimeOfDay
+	loc          *time.Location
+	overrides    []LabeledRange[string]
+}
+
+// MustNewRotation 创建Rotation, 如果参数无效则 panic
+func MustNewRotation(participants []string, anchor Date, shiftDays int, handoff TimeOfDay, loc *time.Location) *Rotation {
+	r, err := NewRotation(participants, anchor, shiftDays, handoff, loc)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// NewRotation 创建Rotation, participants[0] 的第一个班次从 anchor 当天 loc 中的 handoff 时刻开始, 每班持续 shiftDays 天.
+// anchor 之前的时间同样按照轮换顺序向前推算.
+func NewRotation(participants []string, anchor Date, shiftDays int, handoff TimeOfDay, loc *time.Location) (*Rotation, error) {
+	if len(participants) == 0 || shiftDays <= 0 || !anchor.IsValid() || !handoff.IsValid() {
+		return nil, ErrInvalidRotation
+	}
+	return &Rotation{
+		participants: append([]string(nil), participants...),
+		anchor:       anchor,
+		shiftDays:    shiftDays,
+		handoff:      handoff,
+		loc:          loc,
+	}, nil
+}
+
+// AddOverride 添加临时调整, 在 tr 范围内由 who 值班, 覆盖原有的轮换安排. 后添加的调整优先于先添加的调整.
+func (r *Rotation) AddOverride(who string, tr *TimeRange) {
+	r.overrides = append(r.overrides, LabeledRange[string]{Range: tr, Label: who})
+}
+
+// OnCallAt 返回 t 时的值班人
+func (r *Rotation) OnCallAt(t time.Time) string {
+	if label, _, ok := topLabelAt([][]LabeledRange[string]{r.overrides}, t); ok {
+		return label
+	}
+	return r.participants[mod(r.shiftIndexAt(t), len(r.participants))]
+}
+
+// Shifts 返回 window 内的值班安排, 以值班人为标签, 已应用临时调整并裁剪到 window 内. 同一人相邻的班次会被合并.
+func (r *Rotation) Shifts(window *TimeRange) []LabeledRange[string] {
+	resolved := resolveLayers([][]LabeledRange[string]{r.baseShifts(window), r.overrides}, window)
+	shifts := make([]LabeledRange[string], len(resolved))
+	for i, lr := range resolved {
+		shifts[i] = lr.LabeledRange
+	}
+	return shifts
+}
+
+// baseShifts 返回与 window 有交集的所有原始班次
+func (r *Rotation) baseShifts(window *TimeRange) []LabeledRange[string] {
+	var shifts []LabeledRange[string]
+	for k := r.shiftIndexAt(window.start); ; k++ {
+		start := r.shiftStart(k)
+		if window.IsAfterEnd(start) {
+			return shifts
+		}
+		shifts = append(shifts, LabeledRange[string]{
+			Range: &TimeRange{start: start, end: r.shiftStart(k + 1), startInclusive: true},
+			Label: r.participants[mod(k, len(r.participants))],
+		})
+	}
+}
+
+// shiftStart 返回第 k 个班次的开始时间, k 可以为负数
+func (r *Rotation) shiftStart(k int) time.Time {
+	return r.handoff.On(r.anchor.AddDays(k*r.shiftDays), r.loc)
+}
+
+// shiftIndexAt 返回 t 所在班次的序号, anchor 之前的班次序号为负数
+func (r *Rotation) shiftIndexAt(t time.Time) int {
+	k := floorDiv(DateOfByTz(t, r.loc).DaysSince(r.anchor), r.shiftDays)
+	for t.Before(r.shiftStart(k)) {
+		k--
+	}
+	for !t.Before(r.shiftStart(k + 1)) {
+		k++
+	}
+	return k
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func mod(a, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
@@ -0,0 +1,461 @@
+package timex
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecurrence 表示无效的重复规则
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// Frequency 表示重复规则的频率
+type Frequency int
+
+const (
+	FreqDaily Frequency = iota + 1
+	FreqWeekly
+	FreqMonthly
+	FreqYearly
+)
+
+// String 返回 RFC 5545 中的频率名称, 如 "WEEKLY"
+func (f Frequency) String() string {
+	switch f {
+	case FreqDaily:
+		return "DAILY"
+	case FreqWeekly:
+		return "WEEKLY"
+	case FreqMonthly:
+		return "MONTHLY"
+	case FreqYearly:
+		return "YEARLY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// WeekdayNum 表示 BYDAY 中的一项, 如 "MO" (每个周一), "+2TU" (第二个周二), "-1FR" (最后一个周五)
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int // 为 0 时表示每个该星期, 否则表示月内 (FREQ=YEARLY 且没有 BYMONTH 时为年内) 的第 N 个, 负数从后往前数
+}
+
+// RRule 表示 RFC 5545 重复规则 (RRULE) 的一个子集, 支持 FREQ, INTERVAL, BYDAY, BYMONTHDAY, BYMONTH, COUNT 和 UNTIL, 一周从周一开始.
+type RRule struct {
+	Freq       Frequency
+	Interval   int          // 每隔几个周期重复一次, 不大于 0 时按 1 处理
+	ByDay      []WeekdayNum // 只在这些星期重复
+	ByMonthDay []int        // 只在这些日子重复, 1 到 31, 负数表示从月末往前数, 如 -1 表示最后一天
+	ByMonth    []time.Month // 只在这些月份重复
+	Count      int          // 重复的总次数, 为 0 时不限制
+	Until      time.Time    // 最后一次重复不晚于该时间, 为零值时不限制
+}
+
+// Recurrence 是从某个开始时间按照 RRule 展开得到的重复时间序列, 实现了 Schedule
+type Recurrence struct {
+	start time.Time
+	rule  RRule
+}
+
+// maxEmptyPeriods 是连续没有任何重复时间的周期数上限, 超过后认为规则不会再产生重复时间, 以免 BYMONTH=2;BYMONTHDAY=30 这样的规则导致死循环
+const maxEmptyPeriods = 10000
+
+// MustNewRecurrence 创建Recurrence, 如果参数无效则 panic
+func MustNewRecurrence(start time.Time, rule RRule) *Recurrence {
+	r, err := NewRecurrence(start, rule)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// NewRecurrence 创建Recurrence, 重复时间的墙上时刻与 start 相同, 日期按照 start 所在时区的日历推算.
+// 与 RFC 5545 不同, start 本身不满足规则时不会作为第一次重复; 墙上时刻因夏令时切换不存在时向后顺延跳过的时长, 规则与 TimeOfDay.On 相同.
+// BYDAY 中带序号的项只能用于 FREQ=MONTHLY 或 FREQ=YEARLY, BYMONTHDAY 不能用于 FREQ=WEEKLY, 否则返回 ErrInvalidRecurrence.
+func NewRecurrence(start time.Time, rule RRule) (*Recurrence, error) {
+	if rule.Freq < FreqDaily || rule.Freq > FreqYearly || rule.Count < 0 {
+		return nil, ErrInvalidRecurrence
+	}
+	for _, wd := range rule.ByDay {
+		if wd.Weekday < time.Sunday || wd.Weekday > time.Saturday || wd.N < -53 || wd.N > 53 ||
+			(wd.N != 0 && rule.Freq != FreqMonthly && rule.Freq != FreqYearly) {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+	for _, md := range rule.ByMonthDay {
+		if md == 0 || md < -31 || md > 31 || rule.Freq == FreqWeekly {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+	for _, m := range rule.ByMonth {
+		if m < time.January || m > time.December {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+	if rule.Interval <= 0 {
+		rule.Interval = 1
+	}
+	return &Recurrence{start: start, rule: rule}, nil
+}
+
+// Rule 返回重复规则
+func (r *Recurrence) Rule() RRule {
+	return r.rule
+}
+
+// All 依次迭代所有重复时间, 没有 COUNT 和 UNTIL 限制时迭代不会自行结束
+func (r *Recurrence) All() iter.Seq[time.Time] {
+	return r.from(0)
+}
+
+// OccurrencesIn 依次迭代落在时间范围内的重复时间
+func (r *Recurrence) OccurrencesIn(tr *TimeRange) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		r.from(r.periodBefore(tr.start))(func(t time.Time) bool {
+			if tr.IsAfterEnd(t) {
+				return false
+			}
+			return !tr.Contains(t) || yield(t)
+		})
+	}
+}
+
+// Next 返回晚于 after 的第一个重复时间, 实现了 Schedule
+func (r *Recurrence) Next(after time.Time) (time.Time, bool) {
+	var next time.Time
+	r.from(r.periodBefore(after))(func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			return false
+		}
+		return true
+	})
+	return next, !next.IsZero()
+}
+
+// periodBefore 返回可以安全地跳过到的周期序号, 该周期中的重复时间都不晚于 t. 有 COUNT 限制时不能跳过, 总是返回 0.
+func (r *Recurrence) periodBefore(t time.Time) int {
+	if r.rule.Count > 0 || !t.After(r.start) {
+		return 0
+	}
+	loc := r.start.Location()
+	s, d := DateOf(r.start), DateOfByTz(t, loc)
+	var n int
+	switch r.rule.Freq {
+	case FreqDaily:
+		n = d.DaysSince(s)
+	case FreqWeekly:
+		n = truncateDate(d, UnitWeek).DaysSince(truncateDate(s, UnitWeek)) / 7
+	case FreqMonthly:
+		n = monthIndex(d) - monthIndex(s)
+	default:
+		n = d.Year - s.Year
+	}
+	// 往前多留一个周期, 避免当地日期与墙上时刻的偏差导致漏掉重复时间
+	return max(0, n/r.rule.Interval-1)
+}
+
+// from 从第 k 个周期 (以 Interval 为步长计数) 开始迭代重复时间
+func (r *Recurrence) from(k int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		loc := r.start.Location()
+		tod := TimeOfDayOf(r.start)
+		count, empty := 0, 0
+		for ; empty < maxEmptyPeriods; k++ {
+			dates := r.periodDates(k)
+			if len(dates) == 0 {
+				empty++
+				continue
+			}
+			empty = 0
+			for _, d := range dates {
+				t := tod.On(d, loc)
+				if t.Before(r.start) {
+					continue
+				}
+				if !r.rule.Until.IsZero() && t.After(r.rule.Until) {
+					return
+				}
+				if !yield(t) {
+					return
+				}
+				if count++; r.rule.Count > 0 && count >= r.rule.Count {
+					return
+				}
+			}
+		}
+	}
+}
+
+// periodDates 返回第 k 个周期中满足规则的日期, 按日期升序排列
+func (r *Recurrence) periodDates(k int) []Date {
+	rule := r.rule
+	s := DateOf(r.start)
+	n := k * rule.Interval
+
+	var dates []Date
+	switch rule.Freq {
+	case FreqDaily:
+		if d := s.AddDays(n); r.matchDay(d) {
+			dates = append(dates, d)
+		}
+	case FreqWeekly:
+		monday := truncateDate(s, UnitWeek).AddDays(7 * n)
+		for i := 0; i < 7; i++ {
+			d := monday.AddDays(i)
+			if r.matchMonth(d.Month) && (len(rule.ByDay) > 0 && r.matchWeekday(d.Weekday()) || len(rule.ByDay) == 0 && d.Weekday() == s.Weekday()) {
+				dates = append(dates, d)
+			}
+		}
+	case FreqMonthly:
+		year, month := addMonths(s.Year, s.Month, n)
+		if r.matchMonth(month) {
+			dates = r.monthDates(year, month)
+		}
+	case FreqYearly:
+		year := s.Year + n
+		switch {
+		case len(rule.ByMonth) > 0:
+			for _, m := range sortedMonths(rule.ByMonth) {
+				dates = append(dates, r.monthDates(year, m)...)
+			}
+		case len(rule.ByDay) > 0 && len(rule.ByMonthDay) == 0:
+			dates = weekdayDatesIn(Date{Year: year, Month: time.January, Day: 1}, Date{Year: year, Month: time.December, Day: 31}, rule.ByDay)
+		case len(rule.ByMonthDay) > 0:
+			for m := time.January; m <= time.December; m++ {
+				dates = append(dates, r.monthDates(year, m)...)
+			}
+		default:
+			if s.Day <= DaysInMonth(year, s.Month) {
+				dates = append(dates, Date{Year: year, Month: s.Month, Day: s.Day})
+			}
+		}
+	}
+	return dates
+}
+
+// monthDates 返回 year 年 month 月中满足 BYMONTHDAY 和 BYDAY 的日期, 两者都没有时为与开始日期相同的日子, 按日期升序排列
+func (r *Recurrence) monthDates(year int, month time.Month) []Date {
+	rule := r.rule
+	first := Date{Year: year, Month: month, Day: 1}
+	last := Date{Year: year, Month: month, Day: DaysInMonth(year, month)}
+
+	var dates []Date
+	switch {
+	case len(rule.ByMonthDay) > 0:
+		for d := first; !d.After(last); d = d.AddDays(1) {
+			if r.matchMonthDay(d) && (len(rule.ByDay) == 0 || slices.Contains(weekdayDatesIn(first, last, rule.ByDay), d)) {
+				dates = append(dates, d)
+			}
+		}
+	case len(rule.ByDay) > 0:
+		dates = weekdayDatesIn(first, last, rule.ByDay)
+	default:
+		if day := DateOf(r.start).Day; day <= last.Day {
+			dates = append(dates, Date{Year: year, Month: month, Day: day})
+		}
+	}
+	return dates
+}
+
+func (r *Recurrence) matchDay(d Date) bool {
+	return r.matchMonth(d.Month) && (len(r.rule.ByMonthDay) == 0 || r.matchMonthDay(d)) &&
+		(len(r.rule.ByDay) == 0 || r.matchWeekday(d.Weekday()))
+}
+
+func (r *Recurrence) matchMonth(m time.Month) bool {
+	return len(r.rule.ByMonth) == 0 || slices.Contains(r.rule.ByMonth, m)
+}
+
+func (r *Recurrence) matchMonthDay(d Date) bool {
+	n := DaysInMonth(d.Year, d.Month)
+	for _, md := range r.rule.ByMonthDay {
+		if md == d.Day || md < 0 && n+md+1 == d.Day {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Recurrence) matchWeekday(wd time.Weekday) bool {
+	for _, w := range r.rule.ByDay {
+		if w.Weekday == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdayDatesIn 返回 [first, last] 中满足 byDay 的日期, 带序号的项按照在这段日期中的位置计算, 按日期升序排列
+func weekdayDatesIn(first, last Date, byDay []WeekdayNum) []Date {
+	var dates []Date
+	for _, w := range byDay {
+		var all []Date
+		for d := first.AddDays((int(w.Weekday) - int(first.Weekday()) + 7) % 7); !d.After(last); d = d.AddDays(7) {
+			all = append(all, d)
+		}
+		switch {
+		case w.N == 0:
+			dates = append(dates, all...)
+		case w.N > 0 && w.N <= len(all):
+			dates = append(dates, all[w.N-1])
+		case w.N < 0 && -w.N <= len(all):
+			dates = append(dates, all[len(all)+w.N])
+		}
+	}
+	slices.SortFunc(dates, Date.Compare)
+	return slices.Compact(dates)
+}
+
+func sortedMonths(months []time.Month) []time.Month {
+	sorted := slices.Clone(months)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// rruleUntilLayouts 解析 UNTIL 时依次尝试的格式, 不带 "Z" 的格式按照 UTC 解析
+var rruleUntilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// ParseRRule 解析 RFC 5545 格式的重复规则, 如 "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", 可以带有 "RRULE:" 前缀.
+// UNTIL 只有日期时表示该日 UTC 的最后一刻. 不支持的规则部分会返回 ErrInvalidRecurrence.
+func ParseRRule(s string) (RRule, error) {
+	var rule RRule
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return RRule{}, ErrInvalidRecurrence
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Freq = parseFrequency(value)
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			rule.Until, err = parseRRuleUntil(value)
+		case "BYDAY":
+			for _, v := range strings.Split(value, ",") {
+				wd, ok := parseWeekdayNum(v)
+				if !ok {
+					return RRule{}, ErrInvalidRecurrence
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				md, err := strconv.Atoi(v)
+				if err != nil {
+					return RRule{}, ErrInvalidRecurrence
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, md)
+			}
+		case "BYMONTH":
+			for _, v := range strings.Split(value, ",") {
+				m, err := strconv.Atoi(v)
+				if err != nil {
+					return RRule{}, ErrInvalidRecurrence
+				}
+				rule.ByMonth = append(rule.ByMonth, time.Month(m))
+			}
+		default:
+			return RRule{}, ErrInvalidRecurrence
+		}
+		if err != nil {
+			return RRule{}, ErrInvalidRecurrence
+		}
+	}
+	if rule.Freq == 0 {
+		return RRule{}, ErrInvalidRecurrence
+	}
+	return rule, nil
+}
+
+// String 返回 RFC 5545 格式的重复规则, 不带 "RRULE:" 前缀, UNTIL 以 UTC 输出
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		vs := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			vs[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(vs, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		vs := make([]string, len(r.ByMonthDay))
+		for i, md := range r.ByMonthDay {
+			vs[i] = strconv.Itoa(md)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(vs, ","))
+	}
+	if len(r.ByDay) > 0 {
+		vs := make([]string, len(r.ByDay))
+		for i, wd := range r.ByDay {
+			vs[i] = rruleWeekdays[wd.Weekday]
+			if wd.N != 0 {
+				vs[i] = strconv.Itoa(wd.N) + vs[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(vs, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUntilLayouts[0]))
+	}
+	return strings.Join(parts, ";")
+}
+
+func parseFrequency(s string) Frequency {
+	for f := FreqDaily; f <= FreqYearly; f++ {
+		if strings.EqualFold(f.String(), s) {
+			return f
+		}
+	}
+	return 0
+}
+
+func parseWeekdayNum(s string) (WeekdayNum, bool) {
+	if len(s) < 2 {
+		return WeekdayNum{}, false
+	}
+	i := slices.Index(rruleWeekdays, strings.ToUpper(s[len(s)-2:]))
+	if i < 0 {
+		return WeekdayNum{}, false
+	}
+	wd := WeekdayNum{Weekday: time.Weekday(i)}
+	if num := s[:len(s)-2]; num != "" {
+		n, err := strconv.Atoi(num)
+		if err != nil || n == 0 {
+			return WeekdayNum{}, false
+		}
+		wd.N = n
+	}
+	return wd, true
+}
+
+func parseRRuleUntil(s string) (time.Time, error) {
+	var err error
+	for _, layout := range rruleUntilLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			if len(s) == len("20060102") {
+				t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
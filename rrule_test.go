@@ -0,0 +1,226 @@
+package timex
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceExpansion(t *testing.T) {
+	// 2024-01-01 是周一
+	at := func(y int, mo time.Month, d int) time.Time { return time.Date(y, mo, d, 9, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{"FREQ=DAILY;COUNT=3", at(2024, 1, 1), []time.Time{at(2024, 1, 1), at(2024, 1, 2), at(2024, 1, 3)}},
+		{"FREQ=DAILY;INTERVAL=10;COUNT=3", at(2024, 2, 20), []time.Time{at(2024, 2, 20), at(2024, 3, 1), at(2024, 3, 11)}},
+		// 只有日期的 UNTIL 包含当天
+		{"FREQ=WEEKLY;UNTIL=20240122", at(2024, 1, 1), []time.Time{at(2024, 1, 1), at(2024, 1, 8), at(2024, 1, 15), at(2024, 1, 22)}},
+		{"FREQ=WEEKLY;UNTIL=20240122T085959Z", at(2024, 1, 1), []time.Time{at(2024, 1, 1), at(2024, 1, 8), at(2024, 1, 15)}},
+		// 开始时间是周三, 所在周的周一早于开始时间不计入
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=5", at(2024, 1, 3), []time.Time{at(2024, 1, 3), at(2024, 1, 15), at(2024, 1, 17), at(2024, 1, 29), at(2024, 1, 31)}},
+		// 开始时间不满足规则时不作为第一次重复
+		{"FREQ=WEEKLY;BYDAY=FR;COUNT=2", at(2024, 1, 1), []time.Time{at(2024, 1, 5), at(2024, 1, 12)}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", at(2024, 1, 1), []time.Time{at(2024, 1, 26), at(2024, 2, 23), at(2024, 3, 29)}},
+		{"FREQ=MONTHLY;BYDAY=+2TU;COUNT=2", at(2024, 1, 1), []time.Time{at(2024, 1, 9), at(2024, 2, 13)}},
+		// 没有 BYMONTH 时序号按年内计算
+		{"FREQ=YEARLY;BYDAY=1MO;COUNT=3", at(2024, 1, 1), []time.Time{at(2024, 1, 1), at(2025, 1, 6), at(2026, 1, 5)}},
+		{"FREQ=YEARLY;BYDAY=-1SU;COUNT=2", at(2024, 1, 1), []time.Time{at(2024, 12, 29), at(2025, 12, 28)}},
+		{"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=3", at(2024, 1, 1), []time.Time{at(2024, 11, 28), at(2025, 11, 27), at(2026, 11, 26)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=4", at(2024, 1, 15), []time.Time{at(2024, 1, 31), at(2024, 2, 29), at(2024, 3, 31), at(2024, 4, 30)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;COUNT=4", at(2024, 1, 15), []time.Time{at(2024, 1, 31), at(2024, 2, 1), at(2024, 2, 29), at(2024, 3, 1)}},
+		// 没有 31 日的月份被跳过
+		{"FREQ=MONTHLY;BYMONTHDAY=31;COUNT=4", at(2024, 1, 1), []time.Time{at(2024, 1, 31), at(2024, 3, 31), at(2024, 5, 31), at(2024, 7, 31)}},
+		{"FREQ=MONTHLY;COUNT=3", at(2024, 1, 31), []time.Time{at(2024, 1, 31), at(2024, 3, 31), at(2024, 5, 31)}},
+		{"FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;COUNT=2", at(2024, 1, 1), []time.Time{at(2024, 9, 13), at(2024, 12, 13)}},
+		{"FREQ=YEARLY;COUNT=3", at(2024, 2, 29), []time.Time{at(2024, 2, 29), at(2028, 2, 29), at(2032, 2, 29)}},
+		{"FREQ=YEARLY;BYMONTH=3,1;COUNT=3", at(2024, 1, 10), []time.Time{at(2024, 1, 10), at(2024, 3, 10), at(2025, 1, 10)}},
+		{"FREQ=DAILY;BYMONTH=2;BYDAY=SA,SU;COUNT=3", at(2024, 1, 1), []time.Time{at(2024, 2, 3), at(2024, 2, 4), at(2024, 2, 10)}},
+	}
+	for _, c := range cases {
+		rule, err := ParseRRule(c.rule)
+		if err != nil {
+			t.Errorf("ParseRRule(%q): %v", c.rule, err)
+			continue
+		}
+		got := collectTimes(MustNewRecurrence(c.start, rule).All())
+		if !slices.EqualFunc(got, c.want, time.Time.Equal) {
+			t.Errorf("%q from %v = %v, want %v", c.rule, c.start, got, c.want)
+		}
+	}
+}
+
+func TestRecurrenceNeverFires(t *testing.T) {
+	// 2 月没有 30 日, 连续 maxEmptyPeriods 个周期没有重复时间后结束迭代
+	r := MustNewRecurrence(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), RRule{Freq: FreqYearly, ByMonth: []time.Month{time.February}, ByMonthDay: []int{30}})
+	if got := collectTimes(r.All()); len(got) != 0 {
+		t.Errorf("All = %v, want none", got)
+	}
+	if got, ok := r.Next(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Next = %v, want none", got)
+	}
+}
+
+func TestRecurrenceDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	// 2024-03-10 02:30 不存在, 向后顺延一小时
+	r := MustNewRecurrence(time.Date(2024, 3, 9, 2, 30, 0, 0, ny), RRule{Freq: FreqDaily, Count: 3})
+	want := []time.Time{
+		time.Date(2024, 3, 9, 2, 30, 0, 0, ny),
+		time.Date(2024, 3, 10, 3, 30, 0, 0, ny),
+		time.Date(2024, 3, 11, 2, 30, 0, 0, ny),
+	}
+	if got := collectTimes(r.All()); !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+}
+
+// TestRecurrenceNextMatchesScan 检查 Next 和 OccurrencesIn 通过 periodBefore 跳过周期后的结果与从头扫描一致
+func TestRecurrenceNextMatchesScan(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	start := time.Date(2021, 1, 31, 23, 30, 0, 0, ny)
+	rules := []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=3",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+		"FREQ=WEEKLY;BYDAY=SU",
+		"FREQ=MONTHLY",
+		"FREQ=MONTHLY;BYMONTHDAY=-1",
+		"FREQ=MONTHLY;INTERVAL=5;BYDAY=2SU",
+		"FREQ=YEARLY;BYDAY=-1MO",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+		"FREQ=MONTHLY;INTERVAL=13;BYMONTHDAY=29",
+		"FREQ=YEARLY;BYMONTH=1,7;BYMONTHDAY=31;UNTIL=20280101",
+	}
+	end := time.Date(2031, 1, 1, 0, 0, 0, 0, ny)
+	for _, s := range rules {
+		r := MustNewRecurrence(start, mustParseRRule(t, s))
+		var all []time.Time
+		r.All()(func(t time.Time) bool {
+			if t.After(end) {
+				return false
+			}
+			all = append(all, t)
+			return true
+		})
+		if len(all) == 0 {
+			t.Errorf("%q: no occurrences", s)
+			continue
+		}
+
+		var afters []time.Time
+		for a := start.Add(-time.Hour); a.Before(end); a = a.Add(97*time.Hour + 13*time.Minute) {
+			afters = append(afters, a)
+		}
+		// 恰好等于某次重复时间时应返回下一次
+		afters = append(afters, all...)
+		for _, after := range afters {
+			// 扫描范围之外的下一次重复时间未知, 不做检查
+			i := slices.IndexFunc(all, func(t time.Time) bool { return t.After(after) })
+			if i < 0 {
+				continue
+			}
+			if got, ok := r.Next(after); !ok || !got.Equal(all[i]) {
+				t.Errorf("%q.Next(%v) = %v, %v, want %v", s, after, got, ok, all[i])
+			}
+		}
+
+		from, to := time.Date(2026, 5, 17, 12, 0, 0, 0, ny), time.Date(2027, 8, 3, 0, 0, 0, 0, ny)
+		var want []time.Time
+		for _, t := range all {
+			if !t.Before(from) && t.Before(to) {
+				want = append(want, t)
+			}
+		}
+		got := collectTimes(r.OccurrencesIn(MustNewTimeRange(from, to, true, false)))
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("%q.OccurrencesIn = %v, want %v", s, got, want)
+		}
+	}
+}
+
+// mustParseRRule 解析重复规则, 出错时终止测试
+func mustParseRRule(t *testing.T, s string) RRule {
+	t.Helper()
+	rule, err := ParseRRule(s)
+	if err != nil {
+		t.Fatalf("ParseRRule(%q): %v", s, err)
+	}
+	return rule
+}
+
+func TestParseRRuleString(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10"},
+		{"FREQ=MONTHLY;BYDAY=-1FR", "FREQ=MONTHLY;BYDAY=-1FR"},
+		{"FREQ=MONTHLY;BYDAY=+2TU", "FREQ=MONTHLY;BYDAY=2TU"},
+		{"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH"},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20241231T235959Z", "FREQ=MONTHLY;BYMONTHDAY=1,-1;UNTIL=20241231T235959Z"},
+		{"FREQ=DAILY;INTERVAL=1", "FREQ=DAILY"},
+		// 前缀, 大小写, 部分顺序和只有日期的 UNTIL 被规范化
+		{"RRULE:freq=daily;UNTIL=20240131", "FREQ=DAILY;UNTIL=20240131T235959Z"},
+		{"COUNT=3;BYDAY=mo;FREQ=WEEKLY", "FREQ=WEEKLY;BYDAY=MO;COUNT=3"},
+		{"FREQ=YEARLY;UNTIL=20240131T120000", "FREQ=YEARLY;UNTIL=20240131T120000Z"},
+	}
+	for _, c := range cases {
+		rule, err := ParseRRule(c.in)
+		if err != nil {
+			t.Errorf("ParseRRule(%q): %v", c.in, err)
+			continue
+		}
+		if got := rule.String(); got != c.want {
+			t.Errorf("ParseRRule(%q).String() = %q, want %q", c.in, got, c.want)
+		}
+		again, err := ParseRRule(rule.String())
+		if err != nil || again.String() != c.want {
+			t.Errorf("round-trip of %q = %q, %v", c.want, again.String(), err)
+		}
+	}
+
+	rule := mustParseRRule(t, "FREQ=MONTHLY;BYDAY=-1FR,MO;BYMONTH=3;UNTIL=20240131")
+	if want := []WeekdayNum{{Weekday: time.Friday, N: -1}, {Weekday: time.Monday}}; !slices.Equal(rule.ByDay, want) {
+		t.Errorf("ByDay = %v, want %v", rule.ByDay, want)
+	}
+	if want := time.Date(2024, 1, 31, 23, 59, 59, 999999999, time.UTC); !rule.Until.Equal(want) {
+		t.Errorf("Until = %v, want %v", rule.Until, want)
+	}
+}
+
+func TestParseRRuleInvalid(t *testing.T) {
+	for _, s := range []string{
+		"", "FREQ=HOURLY", "COUNT=3", "FREQ=DAILY;BYSETPOS=1", "FREQ=DAILY;COUNT=x", "FREQ=DAILY;INTERVAL",
+		"FREQ=MONTHLY;BYDAY=0MO", "FREQ=DAILY;BYDAY=XX", "FREQ=DAILY;BYDAY=M", "FREQ=DAILY;BYMONTHDAY=a",
+		"FREQ=DAILY;BYMONTH=x", "FREQ=DAILY;UNTIL=2024-01-31",
+	} {
+		if _, err := ParseRRule(s); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("ParseRRule(%q) error = %v, want ErrInvalidRecurrence", s, err)
+		}
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, rule := range []RRule{
+		{},
+		{Freq: FreqDaily, Count: -1},
+		{Freq: FreqWeekly, ByMonthDay: []int{1}},
+		{Freq: FreqWeekly, ByDay: []WeekdayNum{{Weekday: time.Monday, N: 1}}},
+		{Freq: FreqMonthly, ByMonthDay: []int{32}},
+		{Freq: FreqMonthly, ByMonthDay: []int{0}},
+		{Freq: FreqYearly, ByMonth: []time.Month{13}},
+		{Freq: FreqYearly, ByDay: []WeekdayNum{{Weekday: time.Monday, N: 54}}},
+	} {
+		if _, err := NewRecurrence(start, rule); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("NewRecurrence(%v) error = %v, want ErrInvalidRecurrence", rule, err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package timex
+
+import (
+	"hash/fnv"
+	"time"
+)
+
+// SampledIn 根据 t 在其所属周期中的位置决定是否保留 t, 用于需要在多个服务之间保持一致的日志采样.
+// 周期从 unix 零点开始每隔 period 划分, 每个周期中连续的 fraction 比例的时间段会被保留, 该时间段在周期中的起点由 salt 的哈希值决定,
+// 因此相同的参数总是得到相同的结果, 不同的 salt 保留的时间段互不相关. fraction 不大于 0 时总是返回 false, 不小于 1 时总是返回 true.
+// t 需要在 time.UnixNano 能表示的范围内 (1678 年至 2262 年), period 必须大于 0.
+func SampledIn(t time.Time, period time.Duration, fraction float64, salt string) bool {
+	if period <= 0 {
+		panic("timex: non-positive period for SampledIn")
+	}
+	if fraction <= 0 {
+		return false
+	}
+	if fraction >= 1 {
+		return true
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(salt))
+	p := int(period)
+	shift := int(h.Sum64() % uint64(p))
+	pos := mod(mod(int(t.UnixNano()), p)+shift, p)
+	return float64(pos) < fraction*float64(p)
+}
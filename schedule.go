@@ -0,0 +1,208 @@
+package timex
+
+import (
+	"iter"
+	"time"
+)
+
+// Schedule 表示一系列按时间排列的触发时刻, 如每天零点, cron 表达式或重复规则
+type Schedule interface {
+	// Next 返回晚于 after 的第一个触发时刻, 没有更多触发时刻时返回 false
+	Next(after time.Time) (time.Time, bool)
+}
+
+// OccurrencesIn 依次迭代 s 落在时间范围内的触发时刻, 只在迭代时才按需调用 s.Next 计算.
+// limit 大于 0 时最多迭代 limit 个触发时刻, 避免过密的 Schedule (如每秒触发) 在很长的时间范围内迭代过多次; limit 不大于 0 时不限制.
+func OccurrencesIn(s Schedule, tr *TimeRange, limit int) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		t := tr.start.Add(-time.Nanosecond)
+		for n := 0; limit <= 0 || n < limit; {
+			next, ok := s.Next(t)
+			if !ok || tr.IsAfterEnd(next) {
+				return
+			}
+			if tr.Contains(next) {
+				if !yield(next) {
+					return
+				}
+				n++
+			}
+			t = next
+		}
+	}
+}
+
+// NextLocalMidnight 返回晚于 after 的第一个 loc 中的一天的开始时间, 结果位于 loc 中.
+// 零点因夏令时切换而不存在时 (如直接从 23:59:59 跳到 01:00) 返回切换的时刻; 零点出现两次时只返回较早的一次, 保证每个日期只触发一次.
+func NextLocalMidnight(after time.Time, loc *time.Location) time.Time {
+	d := DateOfByTz(after, loc).AddDays(1)
+	t := startOfLocalDay(d, loc)
+	// 在零点回拨到前一天的切换之后, after 的墙上日期会回到前一天, 此时较早的零点已经过去
+	if !t.After(after) {
+		t = startOfLocalDay(d.AddDays(1), loc)
+	}
+	return t
+}
+
+// EachLocalMidnight 返回在 loc 中每天开始时触发的 Schedule, 触发时刻与 NextLocalMidnight 相同
+func EachLocalMidnight(loc *time.Location) Schedule {
+	return localMidnights{loc: loc}
+}
+
+type localMidnights struct {
+	loc *time.Location
+}
+
+func (s localMidnights) Next(after time.Time) (time.Time, bool) {
+	return NextLocalMidnight(after, s.loc), true
+}
+
+// LastDayOfMonth 返回在 loc 中每个月最后一天开始时触发的 Schedule
+func LastDayOfMonth(loc *time.Location) Schedule {
+	return monthlyDays{loc: loc, pick: func(year int, month time.Month) (Date, bool) {
+		return Date{Year: year, Month: month, Day: DaysInMonth(year, month)}, true
+	}}
+}
+
+// LastBusinessDayOfMonth 返回在每个月最后一个工作日开始时触发的 Schedule, 日期和时区都按照 cal 计算, 适合月末结账这类 cron 无法表达的任务
+func LastBusinessDayOfMonth(cal *BusinessCalendar) Schedule {
+	return monthlyDays{loc: cal.Location(), pick: func(year int, month time.Month) (Date, bool) {
+		d := cal.PreviousBusinessDay(Date{Year: year, Month: month, Day: DaysInMonth(year, month)})
+		return d, d.Year == year && d.Month == month
+	}}
+}
+
+// DayOfMonthOrLast 返回在每个月第 n 天开始时触发的 Schedule, 该月没有第 n 天时在最后一天触发, 如 n 为 31 时二月在 28 日或 29 日触发.
+// 日期按照 Next 的 after 自身的时区计算.
+func DayOfMonthOrLast(n int) Schedule {
+	return monthlyDays{pick: func(year int, month time.Month) (Date, bool) {
+		return Date{Year: year, Month: month, Day: max(1, min(n, DaysInMonth(year, month)))}, true
+	}}
+}
+
+// monthlyDays 是每个月在 pick 选出的日期开始时触发的 Schedule, pick 返回 false 表示该月不触发
+type monthlyDays struct {
+	loc  *time.Location // 为 nil 时使用 after 自身的时区
+	pick func(year int, month time.Month) (Date, bool)
+}
+
+func (s monthlyDays) Next(after time.Time) (time.Time, bool) {
+	loc := s.loc
+	if loc == nil {
+		loc = after.Location()
+	}
+	d := DateOfByTz(after, loc)
+	// 整月都不触发时 (如整月都是假日) 继续检查后面的月份, 最多检查十年
+	for i := 0; i < 120; i++ {
+		year, month := addMonths(d.Year, d.Month, i)
+		if day, ok := s.pick(year, month); ok {
+			if t := startOfLocalDay(day, loc); t.After(after) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// RollSchedule 返回将 s 的每个触发时刻按照惯例 conv 调整到 cal 的工作日的 Schedule, 如周末的付款日改为前一个工作日.
+// 调整后的触发时刻保持原来在 cal 时区中的墙上时刻; 多个触发时刻调整到同一时刻时只触发一次, 调整后晚于 after 的触发时刻按时间顺序返回.
+func RollSchedule(s Schedule, cal *BusinessCalendar, conv RollConvention) Schedule {
+	return rolledSchedule{s: s, cal: cal, conv: conv}
+}
+
+type rolledSchedule struct {
+	s    Schedule
+	cal  *BusinessCalendar
+	conv RollConvention
+}
+
+func (s rolledSchedule) Next(after time.Time) (time.Time, bool) {
+	loc := s.cal.Location()
+	// 任何惯例都只会把日期调整到前后最近的工作日, 因此 after 所在日期之前最近的工作日及更早的触发时刻不会被调整到 after 之后
+	from := startOfLocalDay(s.cal.PreviousBusinessDay(DateOfByTz(after, loc).AddDays(-1)).AddDays(1), loc).Add(-time.Nanosecond)
+
+	var best time.Time
+	var stop Date
+	found := false
+	for t, ok := s.s.Next(from); ok; t, ok = s.s.Next(t) {
+		d := DateOfByTz(t, loc)
+		// 同理, best 所在日期之后下一个工作日及更晚的触发时刻调整后都不会早于 best
+		if found && !d.Before(stop) {
+			break
+		}
+		adjusted := t
+		if r := s.cal.Roll(d, s.conv); r != d {
+			adjusted = TimeOfDayOf(t.In(loc)).On(r, loc)
+		}
+		if adjusted.After(after) && (!found || adjusted.Before(best)) {
+			best, found = adjusted, true
+			stop = s.cal.NextBusinessDay(DateOfByTz(best, loc).AddDays(1))
+		}
+	}
+	return best, found
+}
+
+// Every 返回从 start 开始每隔 interval 触发一次的 Schedule, 第一次触发就在 start. 间隔按照绝对时长计算, 不受夏令时切换影响, interval 必须大于 0.
+func Every(start time.Time, interval time.Duration) Schedule {
+	if interval <= 0 {
+		panic("timex: non-positive interval for Every")
+	}
+	return fixedInterval{start: start, interval: interval}
+}
+
+type fixedInterval struct {
+	start    time.Time
+	interval time.Duration
+}
+
+func (s fixedInterval) Next(after time.Time) (time.Time, bool) {
+	if after.Before(s.start) {
+		return s.start, true
+	}
+	n := after.Sub(s.start)/s.interval + 1
+	return s.start.Add(n * s.interval), true
+}
+
+// UnionSchedule 返回在任意一个 Schedule 触发时都触发的 Schedule, 多个 Schedule 在同一时刻触发时只触发一次
+func UnionSchedule(schedules ...Schedule) Schedule {
+	return unionSchedule(schedules)
+}
+
+type unionSchedule []Schedule
+
+func (s unionSchedule) Next(after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, sch := range s {
+		if t, ok := sch.Next(after); ok && (!found || t.Before(next)) {
+			next, found = t, true
+		}
+	}
+	return next, found
+}
+
+// RestrictSchedule 返回只保留 s 中落在时间范围内的触发时刻的 Schedule
+func RestrictSchedule(s Schedule, tr *TimeRange) Schedule {
+	return restrictedSchedule{s: s, tr: tr}
+}
+
+type restrictedSchedule struct {
+	s  Schedule
+	tr *TimeRange
+}
+
+func (s restrictedSchedule) Next(after time.Time) (time.Time, bool) {
+	if from := s.tr.start.Add(-time.Nanosecond); after.Before(from) {
+		after = from
+	}
+	for {
+		t, ok := s.s.Next(after)
+		if !ok || s.tr.IsAfterEnd(t) {
+			return time.Time{}, false
+		}
+		if s.tr.Contains(t) {
+			return t, true
+		}
+		after = t
+	}
+}
@@ -0,0 +1,162 @@
+package timex
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ErrDuplicateSample 表示时间序列中存在时间相同的样本
+var ErrDuplicateSample = errors.New("duplicate sample")
+
+// TimedValue 表示某个时间点的取值, 如一次价格报价或传感器读数
+type TimedValue[V any] struct {
+	T time.Time
+	V V
+}
+
+// AsOfJoin 对每个查询时间, 找出不晚于它的最近一个样本的值, 即查询时刻 "截至当时" 的最新值.
+// samples 需要按时间升序排列, 时间相同的样本取靠后的一个. 返回的两个切片与 queries 一一对应, 查询时间早于所有样本时 ok 为 false, 值为零值.
+func AsOfJoin[V any](queries []time.Time, samples []TimedValue[V]) (values []V, ok []bool) {
+	values, ok = make([]V, len(queries)), make([]bool, len(queries))
+	for i, q := range queries {
+		j := sort.Search(len(samples), func(j int) bool { return samples[j].T.After(q) })
+		if j > 0 {
+			values[i], ok[i] = samples[j-1].V, true
+		}
+	}
+	return values, ok
+}
+
+// Sample 表示时间序列中的一个样本, Null 为 true 时表示该时刻没有有效数据, 此时 V 没有意义
+type Sample struct {
+	T    time.Time
+	V    float64
+	Null bool
+}
+
+// Interp 表示重采样时的插值方式
+type Interp int
+
+const (
+	// InterpPrevious 取不晚于采样时刻的最近一个样本的值
+	InterpPrevious Interp = iota
+	// InterpLinear 在采样时刻前后两个样本之间线性插值
+	InterpLinear
+	// InterpNearest 取离采样时刻最近的样本的值, 距离相同时取较早的样本
+	InterpNearest
+)
+
+// Resample 从 tr 的开始时间起每隔 step 采样一次, 将不规则的样本对齐到规则的时间网格上, 只包含落在 tr 内的采样点. step 不大于 0 时返回 nil.
+// samples 需要按时间升序排列. 采样时刻恰好有样本时直接使用该样本; 否则按照 method 插值,
+// 所需的相邻样本不存在 (如 InterpPrevious 下早于第一个样本, InterpLinear 下超出样本的时间跨度) 或者为 Null 时, 结果为 Null,
+// 因此可以在输入中用 Null 样本标记数据缺失, 避免插值跨越缺口.
+func Resample(samples []Sample, tr *TimeRange, step time.Duration, method Interp) []Sample {
+	if step <= 0 {
+		return nil
+	}
+	var result []Sample
+	for t := tr.start; !t.After(tr.end); t = t.Add(step) {
+		if tr.Contains(t) {
+			result = append(result, interpolate(samples, t, method))
+		}
+	}
+	return result
+}
+
+// interpolate 返回按照 method 在 t 时刻插值得到的样本
+func interpolate(samples []Sample, t time.Time, method Interp) Sample {
+	null := Sample{T: t, Null: true}
+	j := sort.Search(len(samples), func(j int) bool { return samples[j].T.After(t) })
+	var prev, next *Sample
+	if j > 0 {
+		prev = &samples[j-1]
+	}
+	if j < len(samples) {
+		next = &samples[j]
+	}
+	pick := func(s *Sample) Sample {
+		if s == nil {
+			return null
+		}
+		return Sample{T: t, V: s.V, Null: s.Null}
+	}
+	if prev != nil && prev.T.Equal(t) {
+		return pick(prev)
+	}
+
+	switch method {
+	case InterpLinear:
+		if prev == nil || next == nil || prev.Null || next.Null {
+			return null
+		}
+		f := float64(t.Sub(prev.T)) / float64(next.T.Sub(prev.T))
+		return Sample{T: t, V: prev.V + (next.V-prev.V)*f}
+	case InterpNearest:
+		if prev == nil || (next != nil && next.T.Sub(t) < t.Sub(prev.T)) {
+			return pick(next)
+		}
+		return pick(prev)
+	default:
+		return pick(prev)
+	}
+}
+
+// DupStrategy 表示时间序列中多个样本时间相同时的处理方式
+type DupStrategy int
+
+const (
+	// DupKeepFirst 保留输入中靠前的样本
+	DupKeepFirst DupStrategy = iota
+	// DupKeepLast 保留输入中靠后的样本
+	DupKeepLast
+	// DupAverage 取非 Null 样本的平均值, 全部为 Null 时结果为 Null
+	DupAverage
+	// DupError 返回 ErrDuplicateSample
+	DupError
+)
+
+// ResolveDuplicates 按照 strategy 合并时间相同的样本, 返回按时间升序排列且时间互不相同的样本. samples 可以无序, 不会被修改.
+// "靠前" 和 "靠后" 指的是在输入中的顺序.
+func ResolveDuplicates(samples []Sample, strategy DupStrategy) ([]Sample, error) {
+	sorted := slices.Clone(samples)
+	slices.SortStableFunc(sorted, func(a, b Sample) int { return a.T.Compare(b.T) })
+
+	var result []Sample
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j].T.Equal(sorted[i].T) {
+			j++
+		}
+		group := sorted[i:j]
+		i = j
+		if len(group) == 1 {
+			result = append(result, group[0])
+			continue
+		}
+
+		switch strategy {
+		case DupKeepLast:
+			result = append(result, group[len(group)-1])
+		case DupAverage:
+			avg := Sample{T: group[0].T, Null: true}
+			n := 0
+			for _, s := range group {
+				if !s.Null {
+					avg.V += s.V
+					n++
+				}
+			}
+			if n > 0 {
+				avg.V, avg.Null = avg.V/float64(n), false
+			}
+			result = append(result, avg)
+		case DupError:
+			return nil, ErrDuplicateSample
+		default:
+			result = append(result, group[0])
+		}
+	}
+	return result, nil
+}
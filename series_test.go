@@ -0,0 +1,57 @@
+package timex
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestResolveDuplicates(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+	samples := []Sample{
+		{T: at(2), V: 20},
+		{T: at(1), V: 1},
+		{T: at(2).In(time.FixedZone("", 3600)), V: 40}, // 与 at(2) 是同一时刻
+		{T: at(3), Null: true},
+		{T: at(2), Null: true},
+		{T: at(3), Null: true},
+	}
+	input := slices.Clone(samples)
+
+	cases := []struct {
+		strategy DupStrategy
+		want     []Sample
+	}{
+		{DupKeepFirst, []Sample{{T: at(1), V: 1}, {T: at(2), V: 20}, {T: at(3), Null: true}}},
+		{DupKeepLast, []Sample{{T: at(1), V: 1}, {T: at(2), Null: true}, {T: at(3), Null: true}}},
+		{DupAverage, []Sample{{T: at(1), V: 1}, {T: at(2), V: 30}, {T: at(3), Null: true}}},
+	}
+	for _, c := range cases {
+		got, err := ResolveDuplicates(samples, c.strategy)
+		if err != nil {
+			t.Fatalf("ResolveDuplicates(%d): %v", c.strategy, err)
+		}
+		if !slices.EqualFunc(got, c.want, sameSample) {
+			t.Errorf("ResolveDuplicates(%d) = %v, want %v", c.strategy, got, c.want)
+		}
+	}
+	if !slices.EqualFunc(samples, input, sameSample) {
+		t.Error("ResolveDuplicates modified its input")
+	}
+
+	if _, err := ResolveDuplicates(samples, DupError); !errors.Is(err, ErrDuplicateSample) {
+		t.Errorf("DupError: err = %v, want ErrDuplicateSample", err)
+	}
+	unique := []Sample{{T: at(2), V: 2}, {T: at(1), V: 1}}
+	got, err := ResolveDuplicates(unique, DupError)
+	if err != nil || !slices.EqualFunc(got, []Sample{unique[1], unique[0]}, sameSample) {
+		t.Errorf("DupError without duplicates = %v, %v", got, err)
+	}
+}
+
+// sameSample 判断两个样本是否相同, Null 样本不比较取值
+func sameSample(a, b Sample) bool {
+	return a.T.Equal(b.T) && a.Null == b.Null && (a.Null || a.V == b.V)
+}
@@ -0,0 +1,35 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// Sessionize 将事件时间点按照不活跃间隔分组为会话, 相邻两个事件相隔超过 gap 时开始新的会话.
+// 返回按时间排序的会话范围及每个会话包含的事件数, 会话范围为从第一个事件到最后一个事件的闭区间;
+// 只有一个事件 t 的会话为 [t, t+1ns), 因为 [t, t] 不是有效的 TimeRange, 无法编码后再解码.
+// times 可以无序, 不会被修改.
+func Sessionize(times []time.Time, gap time.Duration) ([]*TimeRange, []int) {
+	if len(times) == 0 {
+		return nil, nil
+	}
+	sorted := slices.Clone(times)
+	slices.SortFunc(sorted, func(a, b time.Time) int { return a.Compare(b) })
+
+	var sessions []*TimeRange
+	var counts []int
+	start := 0
+	for i := 1; i <= len(sorted); i++ {
+		if i < len(sorted) && sorted[i].Sub(sorted[i-1]) <= gap {
+			continue
+		}
+		session := &TimeRange{start: sorted[start], end: sorted[i-1], startInclusive: true, endInclusive: true}
+		if i-start == 1 {
+			session.end, session.endInclusive = session.end.Add(time.Nanosecond), false
+		}
+		sessions = append(sessions, session)
+		counts = append(counts, i-start)
+		start = i
+	}
+	return sessions, counts
+}
@@ -0,0 +1,82 @@
+package timex
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSessionize(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	times := []time.Time{
+		t0.Add(40 * time.Minute),
+		t0,
+		t0.Add(5 * time.Minute),
+		// 恰好相隔 gap 时仍属于同一会话
+		t0.Add(40 * time.Minute).Add(30 * time.Minute),
+		t0.Add(3 * time.Hour),
+	}
+	original := slices.Clone(times)
+	sessions, counts := Sessionize(times, 30*time.Minute)
+
+	want := []*TimeRange{
+		MustNewTimeRange(t0, t0.Add(5*time.Minute), true, true),
+		MustNewTimeRange(t0.Add(40*time.Minute), t0.Add(70*time.Minute), true, true),
+		// 只有一个事件的会话为 [t, t+1ns)
+		MustNewTimeRange(t0.Add(3*time.Hour), t0.Add(3*time.Hour+time.Nanosecond), true, false),
+	}
+	if len(sessions) != len(want) {
+		t.Fatalf("Sessionize = %v, want %v", sessions, want)
+	}
+	for i := range want {
+		if sessions[i].String() != want[i].String() {
+			t.Errorf("session %d = %v, want %v", i, sessions[i], want[i])
+		}
+	}
+	if !slices.Equal(counts, []int{2, 2, 1}) {
+		t.Errorf("counts = %v, want [2 2 1]", counts)
+	}
+	if !slices.Equal(times, original) {
+		t.Error("Sessionize modified its input")
+	}
+	if s, c := Sessionize(nil, time.Minute); s != nil || c != nil {
+		t.Errorf("Sessionize(nil) = %v, %v", s, c)
+	}
+}
+
+func TestSessionizeLoneEventEncoding(t *testing.T) {
+	at := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	sessions, _ := Sessionize([]time.Time{at}, time.Minute)
+	lone := sessions[0]
+	if !lone.Contains(at) || lone.Contains(at.Add(time.Nanosecond)) {
+		t.Errorf("lone session %v should contain exactly its event", lone)
+	}
+
+	text, err := lone.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromText TimeRange
+	if err := fromText.UnmarshalText(text); err != nil || fromText.String() != lone.String() {
+		t.Errorf("UnmarshalText(%s) = %v, %v", text, fromText, err)
+	}
+
+	b, err := json.Marshal(lone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON TimeRange
+	if err := json.Unmarshal(b, &fromJSON); err != nil || fromJSON.String() != lone.String() {
+		t.Errorf("json.Unmarshal(%s) = %v, %v", b, fromJSON, err)
+	}
+
+	bin, err := lone.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary TimeRange
+	if err := fromBinary.UnmarshalBinary(bin); err != nil || fromBinary.String() != lone.String() {
+		t.Errorf("UnmarshalBinary = %v, %v", fromBinary, err)
+	}
+}
@@ -0,0 +1,49 @@
+package timex
+
+import "time"
+
+// ShiftPlan 为跨时区旅行生成逐日调整作息的计划, 用于缓解时差反应.
+// anchor 是旅行者在出发地的日常作息时刻 (如 07:00 起床), 从 start 起的第 i 天 (i 从 0 开始, 共 days 天), 结果的第 i 个元素是当天在目的地 toZone 当地执行该作息的时刻.
+// 作息每天最多调整 maxShiftPerDay, 按照两地偏移之差较短的方向 (提前或推后) 调整, 直到与目的地当地的 anchor 一致.
+// 两地的偏移按照 start 当天中午计算. days 不大于 0 或 maxShiftPerDay 不大于 0 时返回 nil.
+func ShiftPlan(fromZone, toZone *time.Location, start Date, anchor TimeOfDay, days int, maxShiftPerDay time.Duration) []TimeOfDay {
+	if days <= 0 || maxShiftPerDay <= 0 {
+		return nil
+	}
+	noon := NewTimeOfDay(12, 0, 0)
+	_, fromOffset := noon.On(start, fromZone).Zone()
+	_, toOffset := noon.On(start, toZone).Zone()
+
+	// 不做调整时, 出发地的 anchor 对应目的地当地的 anchor+diff, 需要调整 -diff 才能与目的地当地的 anchor 一致
+	diff := time.Duration(toOffset-fromOffset) * time.Second
+	need := -diff % (24 * time.Hour)
+	if need > 12*time.Hour {
+		need -= 24 * time.Hour
+	} else if need <= -12*time.Hour {
+		need += 24 * time.Hour
+	}
+
+	plan := make([]TimeOfDay, days)
+	for i := range plan {
+		shift := min(time.Duration(i+1)*maxShiftPerDay, max(need, -need))
+		if need < 0 {
+			shift = -shift
+		}
+		plan[i] = timeOfDayOfDuration(anchor.SinceMidnight() + diff + shift)
+	}
+	return plan
+}
+
+// timeOfDayOfDuration 返回从零点经过 d 之后的时刻, d 超出一天的部分会被舍去, 为负数时从前一天计算
+func timeOfDayOfDuration(d time.Duration) TimeOfDay {
+	d %= 24 * time.Hour
+	if d < 0 {
+		d += 24 * time.Hour
+	}
+	return TimeOfDay{
+		Hour:       int(d / time.Hour),
+		Minute:     int(d % time.Hour / time.Minute),
+		Second:     int(d % time.Minute / time.Second),
+		Nanosecond: int(d % time.Second),
+	}
+}
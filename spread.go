@@ -0,0 +1,98 @@
+package timex
+
+import (
+	"math"
+	"time"
+)
+
+// SpreadRemainder 表示 SpreadOver 中舍入产生的差额计入哪个周期
+type SpreadRemainder int
+
+const (
+	// RemainderLast 计入最后一个周期
+	RemainderLast SpreadRemainder = iota
+	// RemainderFirst 计入第一个周期
+	RemainderFirst
+	// RemainderLargest 计入分摊值绝对值最大的周期, 有多个时取最早的一个
+	RemainderLargest
+)
+
+// SpreadPolicy 表示 SpreadOver 的舍入规则, 零值表示不舍入, 差额计入最后一个周期
+type SpreadPolicy struct {
+	// Precision 大于 0 时各周期的分摊值四舍五入 (远离零) 到它的整数倍, 如 0.01 表示保留到分
+	Precision float64
+	// Remainder 表示舍入后与 quantity 的差额计入哪个周期
+	Remainder SpreadRemainder
+}
+
+// SpreadOver 将数量 quantity 按时长比例分摊到时间范围所跨越的各个日历周期中, 返回以周期第一天为键的分摊结果.
+// 例如将一笔服务期为 1 月 15 日至 3 月 15 日的收入按月确认. 周期按照 loc 中的日历划分, unit 小于 UnitDay 时按 UnitDay 处理.
+// 各周期的分摊值按照 policy 舍入, 差额周期取 quantity 减去其他周期之和 (同样舍入), 因此 quantity 是 Precision 的整数倍时结果之和在该精度下等于 quantity.
+// 时长为 0 的范围全部计入开始时间所在的周期, 不做舍入.
+func SpreadOver(quantity float64, tr *TimeRange, unit Unit, loc *time.Location, policy SpreadPolicy) map[Date]float64 {
+	if unit < UnitDay {
+		unit = UnitDay
+	}
+
+	start, end := tr.start, tr.end
+	total := end.Sub(start)
+	bucket := TruncateTo(start, unit, loc)
+	if total <= 0 {
+		return map[Date]float64{DateOf(bucket.In(loc)): quantity}
+	}
+
+	var keys []Date
+	var shares []float64
+	for bucket.Before(end) {
+		next := addUnits(bucket, unit, 1, loc)
+		s, e := start, end
+		if bucket.After(s) {
+			s = bucket
+		}
+		if next.Before(e) {
+			e = next
+		}
+		keys = append(keys, DateOf(bucket.In(loc)))
+		shares = append(shares, quantity*float64(e.Sub(s))/float64(total))
+		bucket = next
+	}
+
+	absorb := len(shares) - 1
+	switch policy.Remainder {
+	case RemainderFirst:
+		absorb = 0
+	case RemainderLargest:
+		absorb = 0
+		for i, v := range shares {
+			if math.Abs(v) > math.Abs(shares[absorb]) {
+				absorb = i
+			}
+		}
+	}
+	allocated := 0.0
+	for i := range shares {
+		if i != absorb {
+			shares[i] = policy.round(shares[i])
+			allocated += shares[i]
+		}
+	}
+	shares[absorb] = policy.round(quantity - allocated)
+
+	result := make(map[Date]float64, len(keys))
+	for i, key := range keys {
+		result[key] = shares[i]
+	}
+	return result
+}
+
+// round 将 v 四舍五入到 Precision 的整数倍, Precision 不大于 0 时原样返回.
+// Precision 的倒数为整数时 (如 0.01) 通过除以倒数计算, 使结果与十进制的舍入结果最接近.
+func (p SpreadPolicy) round(v float64) float64 {
+	if p.Precision <= 0 {
+		return v
+	}
+	if inv := math.Round(1 / p.Precision); math.Abs(inv*p.Precision-1) < 1e-12 {
+		return math.Round(v*inv) / inv
+	}
+	return math.Round(v/p.Precision) * p.Precision
+}
@@ -0,0 +1,57 @@
+package timex
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSpreadOver(t *testing.T) {
+	// 1 月 1 日至 4 月 1 日, 三个月分别为 31, 29, 31 天
+	tr := MustNewTimeRange(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), true, false)
+	jan, feb, mar := Date{2024, 1, 1}, Date{2024, 2, 1}, Date{2024, 3, 1}
+	cases := []struct {
+		name   string
+		policy SpreadPolicy
+		want   map[Date]float64
+	}{
+		{"cents, remainder last", SpreadPolicy{Precision: 0.01}, map[Date]float64{jan: 340.66, feb: 318.68, mar: 340.65}},
+		{"cents, remainder first", SpreadPolicy{Precision: 0.01, Remainder: RemainderFirst}, map[Date]float64{jan: 340.65, feb: 318.68, mar: 340.66}},
+		{"cents, remainder largest", SpreadPolicy{Precision: 0.01, Remainder: RemainderLargest}, map[Date]float64{jan: 340.65, feb: 318.68, mar: 340.66}},
+		{"whole units", SpreadPolicy{Precision: 1}, map[Date]float64{jan: 341, feb: 319, mar: 340}},
+	}
+	for _, c := range cases {
+		got := SpreadOver(999.99, tr, UnitMonth, time.UTC, c.policy)
+		if len(got) != len(c.want) {
+			t.Fatalf("%s: SpreadOver = %v, want %v", c.name, got, c.want)
+		}
+		sum := 0.0
+		for k, want := range c.want {
+			if math.Abs(got[k]-want) > 1e-9 {
+				t.Errorf("%s: %v = %v, want %v", c.name, k, got[k], want)
+			}
+			sum += got[k]
+		}
+		if c.policy.Precision == 0.01 && math.Abs(sum-999.99) > 1e-9 {
+			t.Errorf("%s: sum = %v, want 999.99", c.name, sum)
+		}
+	}
+
+	raw := SpreadOver(100, tr, UnitMonth, time.UTC, SpreadPolicy{})
+	if want := 100 * 29.0 / 91; math.Abs(raw[feb]-want) > 1e-12 {
+		t.Errorf("unrounded February share = %v, want %v", raw[feb], want)
+	}
+}
+
+func TestSpreadOverPartialBuckets(t *testing.T) {
+	start := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	tr := MustNewTimeRange(start, start.Add(48*time.Hour), true, false)
+	got := SpreadOver(4, tr, UnitDay, time.UTC, SpreadPolicy{})
+	want := map[Date]float64{{2024, 1, 31}: 1, {2024, 2, 1}: 2, {2024, 2, 2}: 1}
+	for k, v := range want {
+		if math.Abs(got[k]-v) > 1e-12 {
+			t.Errorf("%v = %v, want %v", k, got[k], v)
+		}
+	}
+
+}
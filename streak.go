@@ -0,0 +1,34 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// Streaks 计算连续活跃天数, activeDays 为有活动的日期, 可以无序或重复, 晚于今天的日期会被忽略.
+// 今天按照 clock 的当前时间在 loc 中的日期确定. 今天还没有活动时, 截至昨天的连续天数仍然算作当前连续天数, 直到 loc 中的午夜才中断.
+// 返回当前连续天数, 历史最长连续天数, 以及当前连续天数对应的日期范围, 当前连续天数为 0 时日期范围为 nil.
+func Streaks(activeDays []Date, clock Clock, loc *time.Location) (current, longest int, currentRange *DateRange) {
+	today := DateOfByTz(clock.Now(), loc)
+	days := slices.Clone(activeDays)
+	slices.SortFunc(days, Date.Compare)
+	days = slices.Compact(days)
+
+	var start Date
+	run := 0
+	for i, d := range days {
+		if d.After(today) {
+			break
+		}
+		if i > 0 && d.DaysSince(days[i-1]) == 1 {
+			run++
+		} else {
+			run, start = 1, d
+		}
+		longest = max(longest, run)
+		if d == today || d == today.AddDays(-1) {
+			current, currentRange = run, &DateRange{start: start, end: d}
+		}
+	}
+	return current, longest, currentRange
+}
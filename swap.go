@@ -0,0 +1,69 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrSwapDurationMismatch 表示交换的两段安排时长相差过大
+	ErrSwapDurationMismatch = errors.New("swap duration mismatch")
+	// ErrInsufficientRest 表示两段工作之间的休息时间不足
+	ErrInsufficientRest = errors.New("insufficient rest period")
+	// ErrMaxConsecutiveExceeded 表示连续工作时长超过上限
+	ErrMaxConsecutiveExceeded = errors.New("max consecutive work exceeded")
+)
+
+// SwapConstraints 表示交换两段安排时需要满足的规则, 零值表示不做任何检查
+type SwapConstraints struct {
+	// Schedule 是交换前的全部安排, 用于检查交换后每个人的休息时间和连续工作时长, 可以包含被交换的两段安排本身
+	Schedule []LabeledRange[string]
+	// RequireEqualDuration 为 true 时要求两段安排的时长之差不超过 DurationTolerance
+	RequireEqualDuration bool
+	DurationTolerance    time.Duration
+	// MinRest 是两段工作之间的最短休息时间, 为 0 时不检查
+	MinRest time.Duration
+	// MaxConsecutive 是最长的连续工作时长, 首尾相接或重叠的安排视为连续工作, 为 0 时不检查
+	MaxConsecutive time.Duration
+}
+
+// ValidateSwap 检查将 a 和 b 两段安排互换 (a 的值班人改为负责 b 的时间段, 反之亦然) 是否满足 constraints,
+// 不满足时返回 ErrSwapDurationMismatch, ErrInsufficientRest 或 ErrMaxConsecutiveExceeded.
+func ValidateSwap(a, b LabeledRange[string], constraints SwapConstraints) error {
+	if constraints.RequireEqualDuration {
+		diff := a.Range.end.Sub(a.Range.start) - b.Range.end.Sub(b.Range.start)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > constraints.DurationTolerance {
+			return ErrSwapDurationMismatch
+		}
+	}
+	if constraints.MinRest <= 0 && constraints.MaxConsecutive <= 0 {
+		return nil
+	}
+
+	for _, who := range []struct {
+		label      string
+		give, take *TimeRange
+	}{{a.Label, a.Range, b.Range}, {b.Label, b.Range, a.Range}} {
+		worked := []*TimeRange{who.take}
+		for _, lr := range constraints.Schedule {
+			if lr.Label == who.label && !sameRange(lr.Range, who.give) {
+				worked = append(worked, lr.Range)
+			}
+		}
+		if vs := CheckRestPeriods(worked, constraints.MinRest, constraints.MaxConsecutive); len(vs) > 0 {
+			if vs[0].Kind == ViolationInsufficientRest {
+				return ErrInsufficientRest
+			}
+			return ErrMaxConsecutiveExceeded
+		}
+	}
+	return nil
+}
+
+// sameRange 判断两个时间范围的端点及开闭性是否都相同
+func sameRange(a, b *TimeRange) bool {
+	return a.start.Equal(b.start) && a.end.Equal(b.end) && a.startInclusive == b.startInclusive && a.endInclusive == b.endInclusive
+}
@@ -0,0 +1,176 @@
+package timex
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrDuplicateTask 表示任务名称重复
+	ErrDuplicateTask = errors.New("duplicate task")
+	// ErrUnknownTask 表示依赖了不存在的任务
+	ErrUnknownTask = errors.New("unknown task")
+	// ErrTaskCycle 表示任务之间存在循环依赖
+	ErrTaskCycle = errors.New("task dependency cycle")
+)
+
+// TaskSpec 描述任务链中的一个任务
+type TaskSpec struct {
+	Name     string
+	Duration time.Duration // 任务所需的时长, 指定了工作日历时只计算工作时间
+	Parallel bool          // 为 true 时与前一个任务同时开始, 而不是等待前一个任务完成
+}
+
+// ScheduleChain 从 start 开始依次安排任务, 返回每个任务的执行窗口 (以任务名称为标签) 和全部任务的完成时间.
+// 任务默认顺序执行, 即在前面所有任务完成后开始; Parallel 为 true 的任务与前一个任务同时开始, 它们组成的一组任务全部完成后才开始下一个顺序任务.
+// cal 不为 nil 时, 任务只在工作时间内推进, 执行窗口可能跨越非工作时间; cal 为 nil 时按照连续的时间计算.
+func ScheduleChain(start time.Time, tasks []TaskSpec, cal *BusinessCalendar) ([]LabeledRange[string], time.Time) {
+	ranges := make([]LabeledRange[string], 0, len(tasks))
+	groupStart, finish := start, start
+	for i, task := range tasks {
+		if i == 0 || !task.Parallel {
+			groupStart = finish
+		}
+
+		s, e := groupStart, groupStart.Add(task.Duration)
+		if cal != nil {
+			s = cal.nextWorkingInstant(groupStart)
+			e = cal.addWorkingDuration(s, task.Duration)
+		}
+		ranges = append(ranges, LabeledRange[string]{
+			Range: &TimeRange{start: s, end: e, startInclusive: true},
+			Label: task.Name,
+		})
+		if e.After(finish) {
+			finish = e
+		}
+	}
+	return ranges, finish
+}
+
+// Task 描述一个带有依赖关系的任务
+type Task struct {
+	Name      string
+	Duration  time.Duration
+	DependsOn []string // 必须在该任务开始前完成的任务名称
+}
+
+// CriticalPath 计算任务网络的关键路径, 返回关键路径上依次经过的任务名称和完成全部任务所需的总时长.
+// 存在多条关键路径时, 优先选择在 tasks 中靠前的任务.
+func CriticalPath(tasks []Task) ([]string, time.Duration, error) {
+	s, err := scheduleTasks(tasks)
+	if err != nil || len(tasks) == 0 {
+		return nil, 0, err
+	}
+
+	// 从最先在总时长处结束的任务开始, 沿着结束时间恰好等于当前任务开始时间的依赖向前回溯
+	var cur *Task
+	for i := range tasks {
+		if s.earliestStart[tasks[i].Name]+tasks[i].Duration == s.total {
+			cur = &tasks[i]
+			break
+		}
+	}
+	path := []string{cur.Name}
+	for cur != nil {
+		var prev *Task
+		for _, dep := range cur.DependsOn {
+			t := s.byName[dep]
+			if s.earliestStart[dep]+t.Duration == s.earliestStart[cur.Name] && s.slack(t) == 0 {
+				prev = t
+				break
+			}
+		}
+		if prev != nil {
+			path = append(path, prev.Name)
+		}
+		cur = prev
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, s.total, nil
+}
+
+// TaskSlack 返回每个任务的浮动时间, 即在不推迟全部任务完成时间的前提下, 该任务最多可以推迟开始的时长. 关键路径上的任务浮动时间为 0.
+func TaskSlack(tasks []Task) (map[string]time.Duration, error) {
+	s, err := scheduleTasks(tasks)
+	if err != nil {
+		return nil, err
+	}
+	slack := make(map[string]time.Duration, len(tasks))
+	for i := range tasks {
+		slack[tasks[i].Name] = s.slack(&tasks[i])
+	}
+	return slack, nil
+}
+
+// taskSchedule 保存任务网络的最早开始时间和最晚开始时间, 均为相对于整个网络开始时刻的时长
+type taskSchedule struct {
+	byName        map[string]*Task
+	earliestStart map[string]time.Duration
+	latestStart   map[string]time.Duration
+	total         time.Duration
+}
+
+func (s *taskSchedule) slack(t *Task) time.Duration {
+	return s.latestStart[t.Name] - s.earliestStart[t.Name]
+}
+
+func scheduleTasks(tasks []Task) (*taskSchedule, error) {
+	s := &taskSchedule{
+		byName:        make(map[string]*Task, len(tasks)),
+		earliestStart: make(map[string]time.Duration, len(tasks)),
+		latestStart:   make(map[string]time.Duration, len(tasks)),
+	}
+	for i := range tasks {
+		if _, ok := s.byName[tasks[i].Name]; ok {
+			return nil, ErrDuplicateTask
+		}
+		s.byName[tasks[i].Name] = &tasks[i]
+	}
+
+	// 拓扑排序, 同时计算最早开始时间
+	indegree := make(map[string]int, len(tasks))
+	successors := make(map[string][]string, len(tasks))
+	for i := range tasks {
+		for _, dep := range tasks[i].DependsOn {
+			if _, ok := s.byName[dep]; !ok {
+				return nil, ErrUnknownTask
+			}
+			indegree[tasks[i].Name]++
+			successors[dep] = append(successors[dep], tasks[i].Name)
+		}
+	}
+	order := make([]string, 0, len(tasks))
+	for i := range tasks {
+		if indegree[tasks[i].Name] == 0 {
+			order = append(order, tasks[i].Name)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		name := order[i]
+		finish := s.earliestStart[name] + s.byName[name].Duration
+		s.total = max(s.total, finish)
+		for _, next := range successors[name] {
+			s.earliestStart[next] = max(s.earliestStart[next], finish)
+			if indegree[next]--; indegree[next] == 0 {
+				order = append(order, next)
+			}
+		}
+	}
+	if len(order) != len(tasks) {
+		return nil, ErrTaskCycle
+	}
+
+	// 逆序计算最晚开始时间
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		latestFinish := s.total
+		for _, next := range successors[name] {
+			latestFinish = min(latestFinish, s.latestStart[next])
+		}
+		s.latestStart[name] = latestFinish - s.byName[name].Duration
+	}
+	return s, nil
+}
@@ -0,0 +1,39 @@
+package timex
+
+import "time"
+
+// TerminationRule 表示由通知期推算终止日期的规则
+type TerminationRule int
+
+const (
+	// TerminationExact 终止日期为通知日期加上通知期
+	TerminationExact TerminationRule = iota
+	// TerminationEndOfMonth 终止日期为通知期届满当月的最后一天, 如 "提前三个月通知, 于届满当月月底终止"
+	TerminationEndOfMonth
+	// TerminationEndOfQuarter 终止日期为通知期届满当季的最后一天
+	TerminationEndOfQuarter
+	// TerminationEndOfYear 终止日期为通知期届满当年的最后一天
+	TerminationEndOfYear
+)
+
+// TerminationDate 根据通知日期, 通知期和终止规则计算终止日期. 通知期按日历推算, 目标月份没有对应日期时取该月最后一天.
+// cal 不为 nil 且得到的日期不是工作日时: TerminationExact 顺延至之后最近的工作日, 其他规则提前至之前最近的工作日, 以保证终止日期不会落到下一个周期.
+func TerminationDate(noticeGiven Date, noticePeriod Period, rule TerminationRule, cal *BusinessCalendar) Date {
+	d := addPeriodToDate(noticeGiven, noticePeriod, OverflowClamp)
+	switch rule {
+	case TerminationEndOfMonth:
+		d = YearMonth{Year: d.Year, Month: d.Month}.LastDay()
+	case TerminationEndOfQuarter:
+		d = QuarterOf(d.In(time.UTC)).LastDay()
+	case TerminationEndOfYear:
+		d = Date{Year: d.Year, Month: time.December, Day: 31}
+	}
+
+	if cal == nil {
+		return d
+	}
+	if rule == TerminationExact {
+		return cal.rollForward(d)
+	}
+	return cal.rollBackward(d)
+}
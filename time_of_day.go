@@ -0,0 +1,123 @@
+package timex
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidTimeOfDay 表示无效的时刻
+var ErrInvalidTimeOfDay = errors.New("invalid time of day")
+
+// TimeOfDay 表示一天中的某个时刻, 不带日期和时区, 如 09:30.
+// 文本及 JSON 编码格式为 "15:04:05".
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// NewTimeOfDay 创建TimeOfDay
+func NewTimeOfDay(hour, minute, second int) TimeOfDay {
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second}
+}
+
+// TimeOfDayOf 返回时间在其自身时区中的时刻
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	hour, minute, second := t.Clock()
+	return TimeOfDay{Hour: hour, Minute: minute, Second: second, Nanosecond: t.Nanosecond()}
+}
+
+// ParseTimeOfDay 解析 "15:04" 或 "15:04:05" 格式的时刻, 秒可以带小数部分
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return TimeOfDayOf(t), nil
+		}
+	}
+	return TimeOfDay{}, ErrInvalidTimeOfDay
+}
+
+// IsValid 判断是否为 00:00:00 到 23:59:59.999999999 之间的有效时刻
+func (tod TimeOfDay) IsValid() bool {
+	return tod.Hour >= 0 && tod.Hour < 24 && tod.Minute >= 0 && tod.Minute < 60 &&
+		tod.Second >= 0 && tod.Second < 60 && tod.Nanosecond >= 0 && tod.Nanosecond < int(time.Second)
+}
+
+// SinceMidnight 返回从零点到该时刻的墙上时长
+func (tod TimeOfDay) SinceMidnight() time.Duration {
+	return time.Duration(tod.Hour)*time.Hour + time.Duration(tod.Minute)*time.Minute +
+		time.Duration(tod.Second)*time.Second + time.Duration(tod.Nanosecond)
+}
+
+// On 返回指定日期在 loc 中的该时刻.
+// 该时刻因夏令时切换出现两次时返回较早的时间点, 不存在时向后顺延跳过的时长.
+func (tod TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return resolveLocal(d.Year, d.Month, d.Day, tod.Hour, tod.Minute, tod.Second, tod.Nanosecond, loc)
+}
+
+// Compare 比较两个时刻, tod 早于 other 时返回 -1, 晚于时返回 1, 相同时返回 0
+func (tod TimeOfDay) Compare(other TimeOfDay) int {
+	return cmp.Compare(tod.SinceMidnight(), other.SinceMidnight())
+}
+
+// Before 判断 tod 是否早于 other
+func (tod TimeOfDay) Before(other TimeOfDay) bool {
+	return tod.Compare(other) < 0
+}
+
+// After 判断 tod 是否晚于 other
+func (tod TimeOfDay) After(other TimeOfDay) bool {
+	return tod.Compare(other) > 0
+}
+
+// String 返回 "15:04:05" 格式的文本, 有小数秒时会附带小数部分
+func (tod TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", tod.Hour, tod.Minute, tod.Second)
+	if tod.Nanosecond != 0 {
+		s += time.Date(0, 1, 1, 0, 0, 0, tod.Nanosecond, time.UTC).Format(".999999999")
+	}
+	return s
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (tod TimeOfDay) MarshalText() ([]byte, error) {
+	if !tod.IsValid() {
+		return nil, ErrInvalidTimeOfDay
+	}
+	return []byte(tod.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (tod *TimeOfDay) UnmarshalText(text []byte) error {
+	v, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+	*tod = v
+	return nil
+}
+
+// TimeOfDayRange 表示一天中的时段 [From, To), From 晚于 To 时表示跨越零点, 如 22:00 到 06:00; From 与 To 相同时为空时段
+type TimeOfDayRange struct {
+	From TimeOfDay
+	To   TimeOfDay
+}
+
+// Contains 判断时刻是否在时段内
+func (r TimeOfDayRange) Contains(tod TimeOfDay) bool {
+	if r.From.After(r.To) {
+		return !tod.Before(r.From) || tod.Before(r.To)
+	}
+	return !tod.Before(r.From) && tod.Before(r.To)
+}
+
+// containsSpan 判断从 from 开始, 长度为 d 的墙上时段 [from, from+d) 是否完全落在时段内
+func (r TimeOfDayRange) containsSpan(from TimeOfDay, d time.Duration) bool {
+	const day = 24 * time.Hour
+	length := (r.To.SinceMidnight() - r.From.SinceMidnight() + day) % day
+	offset := (from.SinceMidnight() - r.From.SinceMidnight() + day) % day
+	return offset+d <= length
+}
@@ -59,6 +59,12 @@ func (tr *InclusiveTimeRange) IterTimeBy(interval time.Duration) iter.Seq[time.T
 	}
 }
 
+// IterDays 迭代时间范围内 loc 中每一天的开始时间 (通常是零点).
+// 与 IterTimeBy(24 * time.Hour) 不同, 即使某天因夏令时切换只有 23 或 25 小时, 每次得到的也都是当地的零点.
+func (tr *InclusiveTimeRange) IterDays(loc *time.Location) iter.Seq[time.Time] {
+	return iterLocalDays(tr.start, tr.end, tr.Contains, loc)
+}
+
 // IsBeforeStart 这个方法判断给定时间是否在开始时间之前
 func (tr *InclusiveTimeRange) IsBeforeStart(t time.Time) bool {
 	return t.Before(tr.start)
@@ -195,3 +201,75 @@ func (tr *TimeRange) ToInclusiveTimeRange() (*InclusiveTimeRange, error) {
 
 	return NewInclusiveTimeRange(st, et)
 }
+
+// String 以闭区间记法返回时间范围, 端点使用 RFC 3339 格式, 如 "[2024-01-01T00:00:00Z,2024-01-31T23:59:59Z]"
+func (tr InclusiveTimeRange) String() string {
+	return tr.Format(time.RFC3339Nano)
+}
+
+// Format 以闭区间记法返回时间范围, 端点使用 layout 格式化, 以 "," 分隔
+func (tr InclusiveTimeRange) Format(layout string) string {
+	return tr.FormatSep(layout, ",")
+}
+
+// FormatSep 以闭区间记法返回时间范围, 端点使用 layout 格式化, 以 sep 分隔
+func (tr InclusiveTimeRange) FormatSep(layout, sep string) string {
+	return formatInterval(tr.start, tr.end, true, true, layout, sep)
+}
+
+// String 以区间记法返回时间范围, 端点使用 RFC 3339 格式, 如 "[2024-01-01T00:00:00Z,2024-02-01T00:00:00Z)"
+func (tr TimeRange) String() string {
+	return tr.Format(time.RFC3339Nano)
+}
+
+// Format 以区间记法返回时间范围, 端点使用 layout 格式化, 以 "," 分隔, 如 Format(time.DateOnly) 返回 "[2024-01-01,2024-02-01)"
+func (tr TimeRange) Format(layout string) string {
+	return tr.FormatSep(layout, ",")
+}
+
+// FormatSep 以区间记法返回时间范围, 端点使用 layout 格式化, 以 sep 分隔, 如 FormatSep(time.DateOnly, " ~ ") 返回 "[2024-01-01 ~ 2024-02-01)"
+func (tr TimeRange) FormatSep(layout, sep string) string {
+	return formatInterval(tr.start, tr.end, tr.startInclusive, tr.endInclusive, layout, sep)
+}
+
+// IterDays 迭代时间范围内 loc 中每一天的开始时间 (通常是零点), 即使某天因夏令时切换只有 23 或 25 小时, 每次得到的也都是当地的零点
+func (tr *TimeRange) IterDays(loc *time.Location) iter.Seq[time.Time] {
+	return iterLocalDays(tr.start, tr.end, tr.Contains, loc)
+}
+
+// IterBusinessDays 依次迭代与时间范围有重叠的 loc 中的每个工作日, 周六和周日不是工作日, 节假日由 cal 提供, cal 可以为 nil.
+// cal 实现了 MakeupWorkdayCalendar 时, 落在周末的调休上班日也会被迭代. 需要自定义周末时请使用 BusinessCalendar.IsBusinessDay 配合 IterDays.
+func (tr *TimeRange) IterBusinessDays(cal HolidayCalendar, loc *time.Location) iter.Seq[Date] {
+	bc := calendarOrDefault(nil, loc)
+	bc.SetHolidayCalendar(cal)
+	first, last := tr.start, tr.end
+	if !tr.startInclusive {
+		first = first.Add(time.Nanosecond)
+	}
+	if !tr.endInclusive {
+		last = last.Add(-time.Nanosecond)
+	}
+	return func(yield func(Date) bool) {
+		end := DateOfByTz(last, loc)
+		for d := DateOfByTz(first, loc); !d.After(end); d = d.AddDays(1) {
+			if bc.IsBusinessDay(d) && !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// iterLocalDays 迭代 [start, end] 之间满足 contains 的 loc 中每一天的开始时间, 当天零点因夏令时切换而不存在时为切换的时刻
+func iterLocalDays(start, end time.Time, contains func(time.Time) bool, loc *time.Location) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		for d := DateOfByTz(start, loc); ; d = d.AddDays(1) {
+			t := startOfLocalDay(d, loc)
+			if t.After(end) {
+				return
+			}
+			if contains(t) && !yield(t) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package timex
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidBinaryTimeRange 表示无法解码的二进制时间范围数据
+var ErrInvalidBinaryTimeRange = errors.New("invalid binary time range")
+
+const (
+	binaryTimeRangeVersion = 1
+	binaryTimeRangeSize    = 2 + 2*binaryEndpointSize
+	binaryEndpointSize     = 8 + 4 + 4 // unix 秒, 纳秒, 时区偏移秒数
+
+	binaryStartInclusive = 1 << 0
+	binaryEndInclusive   = 1 << 1
+	binaryStartUTC       = 1 << 2
+	binaryEndUTC         = 1 << 3
+)
+
+// pgTimestampLayout 写入 Postgres 时使用的时间格式
+const pgTimestampLayout = "2006-01-02 15:04:05.999999-07:00"
+
+// pgTimestampLayouts 读取 Postgres 范围字面量时依次尝试的时间格式, 小数秒在解析时会被自动识别.
+// 不带偏移的格式对应 tsrange, 解析结果为 UTC 时间.
+var pgTimestampLayouts = []string{
+	"2006-01-02 15:04:05-07",
+	"2006-01-02 15:04:05-07:00",
+	"2006-01-02 15:04:05-07:00:00",
+	"2006-01-02 15:04:05",
+}
+
+// MarshalText 实现 encoding.TextMarshaler, 以区间记法输出, 端点使用 RFC 3339 格式, 如 "[2024-01-01T00:00:00Z,2024-02-01T00:00:00Z)"
+func (tr TimeRange) MarshalText() ([]byte, error) {
+	return []byte(tr.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler, 接受 MarshalText 输出的格式
+func (tr *TimeRange) UnmarshalText(text []byte) error {
+	start, end, startInclusive, endInclusive, err := parseInterval(string(text))
+	if err != nil {
+		return err
+	}
+	r, err := NewTimeRange(start, end, startInclusive, endInclusive)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+// MarshalText 实现 encoding.TextMarshaler, 以闭区间记法输出, 如 "[2024-01-01T00:00:00Z,2024-01-31T23:59:59Z]"
+func (tr InclusiveTimeRange) MarshalText() ([]byte, error) {
+	return []byte(tr.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler, 只接受闭区间记法
+func (tr *InclusiveTimeRange) UnmarshalText(text []byte) error {
+	start, end, startInclusive, endInclusive, err := parseInterval(string(text))
+	if err != nil {
+		return err
+	}
+	if !startInclusive || !endInclusive {
+		return ErrInvalidTimeRange
+	}
+	r, err := NewInclusiveTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+// Value 实现 driver.Valuer, 输出 Postgres tstzrange 字面量, 如 ["2024-01-01 00:00:00+00:00","2024-02-01 00:00:00+00:00").
+// Postgres 的时间精度为微秒, 更细的部分会被截断; 写入 tsrange 列时时区偏移会被忽略, 建议先转换为 UTC.
+func (tr TimeRange) Value() (driver.Value, error) {
+	return formatPgRange(tr.start, tr.end, tr.startInclusive, tr.endInclusive), nil
+}
+
+// Scan 实现 sql.Scanner, 读取 Postgres tstzrange / tsrange 字面量, 保留边界的开闭性.
+// 不支持空范围和无穷边界.
+func (tr *TimeRange) Scan(src any) error {
+	start, end, startInclusive, endInclusive, err := scanPgRange(src)
+	if err != nil {
+		return err
+	}
+	r, err := NewTimeRange(start, end, startInclusive, endInclusive)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+// Value 实现 driver.Valuer, 输出闭区间的 Postgres tstzrange 字面量
+func (tr InclusiveTimeRange) Value() (driver.Value, error) {
+	return formatPgRange(tr.start, tr.end, true, true), nil
+}
+
+// Scan 实现 sql.Scanner, 读取 Postgres tstzrange / tsrange 字面量, 只接受闭区间
+func (tr *InclusiveTimeRange) Scan(src any) error {
+	start, end, startInclusive, endInclusive, err := scanPgRange(src)
+	if err != nil {
+		return err
+	}
+	if !startInclusive || !endInclusive {
+		return ErrInvalidTimeRange
+	}
+	r, err := NewInclusiveTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 使用固定 34 字节的布局:
+// 1 字节版本号, 1 字节标志位 (开闭性及端点是否为 UTC), 随后依次是开始和结束时间的 unix 秒 (int64), 纳秒 (int32), 时区偏移秒数 (int32), 均为大端序.
+// 与 time.Time 的二进制编码一样, 只保留时区偏移, 不保留时区名称. 实现该接口后 TimeRange 也可以直接用于 gob.
+func (tr TimeRange) MarshalBinary() ([]byte, error) {
+	return marshalBinaryRange(tr.start, tr.end, tr.startInclusive, tr.endInclusive), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler
+func (tr *TimeRange) UnmarshalBinary(data []byte) error {
+	start, end, startInclusive, endInclusive, err := unmarshalBinaryRange(data)
+	if err != nil {
+		return err
+	}
+	r, err := NewTimeRange(start, end, startInclusive, endInclusive)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+// MarshalBinary 实现 encoding.BinaryMarshaler, 布局与 TimeRange 相同, 两端均标记为包含
+func (tr InclusiveTimeRange) MarshalBinary() ([]byte, error) {
+	return marshalBinaryRange(tr.start, tr.end, true, true), nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler, 只接受两端均包含的数据
+func (tr *InclusiveTimeRange) UnmarshalBinary(data []byte) error {
+	start, end, startInclusive, endInclusive, err := unmarshalBinaryRange(data)
+	if err != nil {
+		return err
+	}
+	if !startInclusive || !endInclusive {
+		return ErrInvalidTimeRange
+	}
+	r, err := NewInclusiveTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+	*tr = *r
+	return nil
+}
+
+func marshalBinaryRange(start, end time.Time, startInclusive, endInclusive bool) []byte {
+	var flags byte
+	if startInclusive {
+		flags |= binaryStartInclusive
+	}
+	if endInclusive {
+		flags |= binaryEndInclusive
+	}
+	if start.Location() == time.UTC {
+		flags |= binaryStartUTC
+	}
+	if end.Location() == time.UTC {
+		flags |= binaryEndUTC
+	}
+
+	b := make([]byte, 2, binaryTimeRangeSize)
+	b[0], b[1] = binaryTimeRangeVersion, flags
+	b = appendBinaryEndpoint(b, start)
+	b = appendBinaryEndpoint(b, end)
+	return b
+}
+
+func appendBinaryEndpoint(b []byte, t time.Time) []byte {
+	_, offset := t.Zone()
+	b = binary.BigEndian.AppendUint64(b, uint64(t.Unix()))
+	b = binary.BigEndian.AppendUint32(b, uint32(t.Nanosecond()))
+	return binary.BigEndian.AppendUint32(b, uint32(int32(offset)))
+}
+
+func unmarshalBinaryRange(data []byte) (start, end time.Time, startInclusive, endInclusive bool, err error) {
+	if len(data) != binaryTimeRangeSize || data[0] != binaryTimeRangeVersion {
+		return start, end, false, false, ErrInvalidBinaryTimeRange
+	}
+	flags := data[1]
+	start = readBinaryEndpoint(data[2:], flags&binaryStartUTC != 0)
+	end = readBinaryEndpoint(data[2+binaryEndpointSize:], flags&binaryEndUTC != 0)
+	return start, end, flags&binaryStartInclusive != 0, flags&binaryEndInclusive != 0, nil
+}
+
+func readBinaryEndpoint(b []byte, utc bool) time.Time {
+	sec := int64(binary.BigEndian.Uint64(b))
+	nsec := int64(binary.BigEndian.Uint32(b[8:]))
+	offset := int(int32(binary.BigEndian.Uint32(b[12:])))
+
+	t := time.Unix(sec, nsec)
+	if utc {
+		return t.UTC()
+	}
+	// 与 time.Time.UnmarshalBinary 一致, 偏移与本地时区相同时使用 time.Local
+	if _, localOffset := t.Zone(); localOffset == offset {
+		return t
+	}
+	return t.In(time.FixedZone("", offset))
+}
+
+func formatPgRange(start, end time.Time, startInclusive, endInclusive bool) string {
+	return formatInterval(start, end, startInclusive, endInclusive, `"`+pgTimestampLayout+`"`, ",")
+}
+
+func scanPgRange(src any) (start, end time.Time, startInclusive, endInclusive bool, err error) {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return start, end, false, false, fmt.Errorf("timex: cannot scan %T into time range", src)
+	}
+
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+	startInclusive, endInclusive = s[0] == '[', s[len(s)-1] == ']'
+	if (s[0] != '[' && s[0] != '(') || (s[len(s)-1] != ']' && s[len(s)-1] != ')') {
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+	startStr, endStr, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok {
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+	if start, err = parsePgTimestamp(startStr); err != nil {
+		return start, end, false, false, err
+	}
+	if end, err = parsePgTimestamp(endStr); err != nil {
+		return start, end, false, false, err
+	}
+	return start, end, startInclusive, endInclusive, nil
+}
+
+func parsePgTimestamp(s string) (time.Time, error) {
+	s = strings.Trim(strings.TrimSpace(s), `"`)
+	if s == "" {
+		return time.Time{}, ErrInvalidTimeRange
+	}
+	var err error
+	for _, layout := range pgTimestampLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func formatInterval(start, end time.Time, startInclusive, endInclusive bool, layout, sep string) string {
+	var sb strings.Builder
+	if startInclusive {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+	sb.WriteString(start.Format(layout))
+	sb.WriteString(sep)
+	sb.WriteString(end.Format(layout))
+	if endInclusive {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+	return sb.String()
+}
+
+func parseInterval(s string) (start, end time.Time, startInclusive, endInclusive bool, err error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+
+	switch s[0] {
+	case '[':
+		startInclusive = true
+	case '(':
+	default:
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+	switch s[len(s)-1] {
+	case ']':
+		endInclusive = true
+	case ')':
+	default:
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+
+	startStr, endStr, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok {
+		return start, end, false, false, ErrInvalidTimeRange
+	}
+	if start, err = time.Parse(time.RFC3339Nano, strings.TrimSpace(startStr)); err != nil {
+		return start, end, false, false, err
+	}
+	if end, err = time.Parse(time.RFC3339Nano, strings.TrimSpace(endStr)); err != nil {
+		return start, end, false, false, err
+	}
+	return start, end, startInclusive, endInclusive, nil
+}
@@ -0,0 +1,55 @@
+package timex
+
+import "time"
+
+// TimeRangeSet 表示一组时间范围的并集, 如维护窗口, 封网时段. 其中的范围按时间排序, 重叠或相接的范围会被合并.
+type TimeRangeSet struct {
+	ranges []*TimeRange
+}
+
+// NewTimeRangeSet 创建TimeRangeSet
+func NewTimeRangeSet(ranges ...*TimeRange) TimeRangeSet {
+	return TimeRangeSet{
+		ranges: depthRuns(depthProfile(ranges), func(depth int) bool { return depth > 0 }, true),
+	}
+}
+
+// Ranges 返回合并后按时间排序的时间范围
+func (s TimeRangeSet) Ranges() []*TimeRange {
+	return s.ranges
+}
+
+// IsEmpty 判断集合是否为空
+func (s TimeRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Contains 判断时间是否落在集合中的某个范围内
+func (s TimeRangeSet) Contains(t time.Time) bool {
+	return s.rangeAt(t) != nil
+}
+
+// rangeAt 返回包含 t 的范围, 不存在时返回 nil
+func (s TimeRangeSet) rangeAt(t time.Time) *TimeRange {
+	for _, r := range s.ranges {
+		if r.IsBeforeStart(t) {
+			return nil
+		}
+		if r.Contains(t) {
+			return r
+		}
+	}
+	return nil
+}
+
+// nextOutside 返回不早于 t 且不在集合内的第一个时间点
+func (s TimeRangeSet) nextOutside(t time.Time) time.Time {
+	r := s.rangeAt(t)
+	if r == nil {
+		return t
+	}
+	if r.endInclusive {
+		return r.end.Add(time.Nanosecond)
+	}
+	return r.end
+}
@@ -1,6 +1,12 @@
 package timex
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoSuchWeekday 表示指定月份中不存在所要求的第 n 个星期几
+var ErrNoSuchWeekday = errors.New("no such weekday in month")
 
 func StartOfDay(t time.Time) time.Time {
 	return StartOfDayByTz(t, t.Location())
@@ -20,3 +26,241 @@ func IsStartOfDayByTz(t time.Time, loc *time.Location) bool {
 	t = t.In(loc)
 	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
 }
+
+// StartOfQuarter 返回时间在其自身时区中所属季度的第一天零点
+func StartOfQuarter(t time.Time) time.Time {
+	return StartOfQuarterByTz(t, t.Location())
+}
+
+// StartOfQuarterByTz 返回时间在指定时区中所属季度的第一天零点, 零点因夏令时切换而不存在时返回切换的时刻, 与 TruncateTo(t, UnitQuarter, loc) 相同
+func StartOfQuarterByTz(t time.Time, loc *time.Location) time.Time {
+	return TruncateTo(t, UnitQuarter, loc)
+}
+
+// EndOfQuarter 返回时间在其自身时区中所属季度的最后一刻, 即下季度第一天零点之前的 1 纳秒
+func EndOfQuarter(t time.Time) time.Time {
+	return EndOfQuarterByTz(t, t.Location())
+}
+
+// EndOfQuarterByTz 返回时间在指定时区中所属季度的最后一刻, 即下季度第一天零点之前的 1 纳秒
+func EndOfQuarterByTz(t time.Time, loc *time.Location) time.Time {
+	return addUnits(StartOfQuarterByTz(t, loc), UnitQuarter, 1, loc).Add(-time.Nanosecond)
+}
+
+// StartOfHour 返回时间在其自身时区中所属小时的开始时间
+func StartOfHour(t time.Time) time.Time {
+	return StartOfHourByTz(t, t.Location())
+}
+
+// StartOfHourByTz 返回时间在指定时区中所属小时的开始时间.
+// 与 time.Time.Truncate 不同, 它按照墙上时间截断, 在偏移不是整小时的时区 (如 Asia/Kolkata) 中也能得到本地的整点.
+func StartOfHourByTz(t time.Time, loc *time.Location) time.Time {
+	return TruncateTo(t, UnitHour, loc)
+}
+
+// StartOfMinute 返回时间在其自身时区中所属分钟的开始时间
+func StartOfMinute(t time.Time) time.Time {
+	return StartOfMinuteByTz(t, t.Location())
+}
+
+// StartOfMinuteByTz 返回时间在指定时区中所属分钟的开始时间
+func StartOfMinuteByTz(t time.Time, loc *time.Location) time.Time {
+	return TruncateTo(t, UnitMinute, loc)
+}
+
+// StartOfSecond 返回时间在其自身时区中所属秒的开始时间
+func StartOfSecond(t time.Time) time.Time {
+	return StartOfSecondByTz(t, t.Location())
+}
+
+// StartOfSecondByTz 返回时间在指定时区中所属秒的开始时间
+func StartOfSecondByTz(t time.Time, loc *time.Location) time.Time {
+	return TruncateTo(t, UnitSecond, loc)
+}
+
+// IsEndOfDay 判断时间是否为其自身时区中某天的最后一刻, 即次日开始时间之前的 1 纳秒
+func IsEndOfDay(t time.Time) bool {
+	return IsEndOfDayByTz(t, t.Location())
+}
+
+// IsEndOfDayByTz 判断时间是否为指定时区中某天的最后一刻, 即次日开始时间之前的 1 纳秒
+func IsEndOfDayByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t.Add(time.Nanosecond), UnitDay, loc)
+}
+
+// IsStartOfWeek 判断时间是否为其自身时区中某周的开始时间, 周以周一为开始
+func IsStartOfWeek(t time.Time) bool {
+	return IsStartOfWeekByTz(t, t.Location())
+}
+
+// IsStartOfWeekByTz 判断时间是否为指定时区中某周的开始时间, 周以周一为开始
+func IsStartOfWeekByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t, UnitWeek, loc)
+}
+
+// IsEndOfWeek 判断时间是否为其自身时区中某周的最后一刻, 即下一周开始时间之前的 1 纳秒
+func IsEndOfWeek(t time.Time) bool {
+	return IsEndOfWeekByTz(t, t.Location())
+}
+
+// IsEndOfWeekByTz 判断时间是否为指定时区中某周的最后一刻, 即下一周开始时间之前的 1 纳秒
+func IsEndOfWeekByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t.Add(time.Nanosecond), UnitWeek, loc)
+}
+
+// IsStartOfMonth 判断时间是否为其自身时区中某月的开始时间
+func IsStartOfMonth(t time.Time) bool {
+	return IsStartOfMonthByTz(t, t.Location())
+}
+
+// IsStartOfMonthByTz 判断时间是否为指定时区中某月的开始时间
+func IsStartOfMonthByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t, UnitMonth, loc)
+}
+
+// IsEndOfMonth 判断时间是否为其自身时区中某月的最后一刻, 即下一月开始时间之前的 1 纳秒
+func IsEndOfMonth(t time.Time) bool {
+	return IsEndOfMonthByTz(t, t.Location())
+}
+
+// IsEndOfMonthByTz 判断时间是否为指定时区中某月的最后一刻, 即下一月开始时间之前的 1 纳秒
+func IsEndOfMonthByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t.Add(time.Nanosecond), UnitMonth, loc)
+}
+
+// IsStartOfQuarter 判断时间是否为其自身时区中某季度的开始时间
+func IsStartOfQuarter(t time.Time) bool {
+	return IsStartOfQuarterByTz(t, t.Location())
+}
+
+// IsStartOfQuarterByTz 判断时间是否为指定时区中某季度的开始时间
+func IsStartOfQuarterByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t, UnitQuarter, loc)
+}
+
+// IsEndOfQuarter 判断时间是否为其自身时区中某季度的最后一刻, 即下一季度开始时间之前的 1 纳秒
+func IsEndOfQuarter(t time.Time) bool {
+	return IsEndOfQuarterByTz(t, t.Location())
+}
+
+// IsEndOfQuarterByTz 判断时间是否为指定时区中某季度的最后一刻, 即下一季度开始时间之前的 1 纳秒
+func IsEndOfQuarterByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t.Add(time.Nanosecond), UnitQuarter, loc)
+}
+
+// IsStartOfYear 判断时间是否为其自身时区中某年的开始时间
+func IsStartOfYear(t time.Time) bool {
+	return IsStartOfYearByTz(t, t.Location())
+}
+
+// IsStartOfYearByTz 判断时间是否为指定时区中某年的开始时间
+func IsStartOfYearByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t, UnitYear, loc)
+}
+
+// IsEndOfYear 判断时间是否为其自身时区中某年的最后一刻, 即下一年开始时间之前的 1 纳秒
+func IsEndOfYear(t time.Time) bool {
+	return IsEndOfYearByTz(t, t.Location())
+}
+
+// IsEndOfYearByTz 判断时间是否为指定时区中某年的最后一刻, 即下一年开始时间之前的 1 纳秒
+func IsEndOfYearByTz(t time.Time, loc *time.Location) bool {
+	return isBoundary(t.Add(time.Nanosecond), UnitYear, loc)
+}
+
+// IsSameDay 判断两个时间在 a 的时区中是否属于同一天
+func IsSameDay(a, b time.Time) bool {
+	return IsSameDayByTz(a, b, a.Location())
+}
+
+// IsSameDayByTz 判断两个时间在指定时区中是否属于同一天
+func IsSameDayByTz(a, b time.Time, loc *time.Location) bool {
+	return TruncateTo(a, UnitDay, loc).Equal(TruncateTo(b, UnitDay, loc))
+}
+
+// IsSameWeek 判断两个时间在 a 的时区中是否属于同一周, 周以周一为开始
+func IsSameWeek(a, b time.Time) bool {
+	return IsSameWeekByTz(a, b, a.Location())
+}
+
+// IsSameWeekByTz 判断两个时间在指定时区中是否属于同一周, 周以周一为开始
+func IsSameWeekByTz(a, b time.Time, loc *time.Location) bool {
+	return TruncateTo(a, UnitWeek, loc).Equal(TruncateTo(b, UnitWeek, loc))
+}
+
+// IsSameMonth 判断两个时间在 a 的时区中是否属于同一月
+func IsSameMonth(a, b time.Time) bool {
+	return IsSameMonthByTz(a, b, a.Location())
+}
+
+// IsSameMonthByTz 判断两个时间在指定时区中是否属于同一月
+func IsSameMonthByTz(a, b time.Time, loc *time.Location) bool {
+	return TruncateTo(a, UnitMonth, loc).Equal(TruncateTo(b, UnitMonth, loc))
+}
+
+// IsSameYear 判断两个时间在 a 的时区中是否属于同一年
+func IsSameYear(a, b time.Time) bool {
+	return IsSameYearByTz(a, b, a.Location())
+}
+
+// IsSameYearByTz 判断两个时间在指定时区中是否属于同一年
+func IsSameYearByTz(a, b time.Time, loc *time.Location) bool {
+	return TruncateTo(a, UnitYear, loc).Equal(TruncateTo(b, UnitYear, loc))
+}
+
+// NextWeekday 返回 t 之后第一个星期为 w 的日期中与 t 墙上时刻相同的时间, t 本身的星期为 w 时返回一周之后.
+// 日期按照 t 的时区计算, 跨越夏令时切换时墙上时刻保持不变, 规则与 AddDays 相同.
+func NextWeekday(t time.Time, w time.Weekday) time.Time {
+	return AddDays(t, (int(w)-int(t.Weekday())+6)%7+1, t.Location())
+}
+
+// NextOrSameWeekday 与 NextWeekday 相同, 但 t 本身的星期为 w 时直接返回 t
+func NextOrSameWeekday(t time.Time, w time.Weekday) time.Time {
+	return AddDays(t, (int(w)-int(t.Weekday())+7)%7, t.Location())
+}
+
+// PreviousWeekday 返回 t 之前最后一个星期为 w 的日期中与 t 墙上时刻相同的时间, t 本身的星期为 w 时返回一周之前
+func PreviousWeekday(t time.Time, w time.Weekday) time.Time {
+	return AddDays(t, -((int(t.Weekday())-int(w)+6)%7 + 1), t.Location())
+}
+
+// PreviousOrSameWeekday 与 PreviousWeekday 相同, 但 t 本身的星期为 w 时直接返回 t
+func PreviousOrSameWeekday(t time.Time, w time.Weekday) time.Time {
+	return AddDays(t, -((int(t.Weekday()) - int(w) + 7) % 7), t.Location())
+}
+
+// NthWeekdayOfMonth 返回 year 年 month 月的第 n 个星期 weekday 在 loc 中的开始时间, 如 3 月的第 3 个周五.
+// n 从 1 开始, 该月没有第 n 个 weekday (如第 5 个周一) 时返回 ErrNoSuchWeekday.
+func NthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int, loc *time.Location) (time.Time, error) {
+	d, ok := nthWeekdayDate(year, month, weekday, n)
+	if !ok {
+		return time.Time{}, ErrNoSuchWeekday
+	}
+	return startOfLocalDay(d, loc), nil
+}
+
+// LastWeekdayOfMonth 返回 year 年 month 月的最后一个星期 weekday 在 loc 中的开始时间, 如 11 月的最后一个周四
+func LastWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, loc *time.Location) time.Time {
+	return startOfLocalDay(lastWeekdayDate(year, month, weekday), loc)
+}
+
+// isBoundary 判断 t 是否恰好为 loc 中某个 unit 周期的开始时间
+func isBoundary(t time.Time, unit Unit, loc *time.Location) bool {
+	return TruncateTo(t, unit, loc).Equal(t)
+}
+
+// nthWeekdayDate 返回 year 年 month 月的第 n 个星期 weekday, 不存在时返回 false
+func nthWeekdayDate(year int, month time.Month, weekday time.Weekday, n int) (Date, bool) {
+	if n < 1 || month < time.January || month > time.December {
+		return Date{}, false
+	}
+	first := Date{Year: year, Month: month, Day: 1}
+	d := first.AddDays((int(weekday)-int(first.Weekday())+7)%7 + (n-1)*7)
+	return d, d.Month == month
+}
+
+// lastWeekdayDate 返回 year 年 month 月的最后一个星期 weekday, 超出范围的月份会像 time.Date 一样被规范化
+func lastWeekdayDate(year int, month time.Month, weekday time.Weekday) Date {
+	last := NewDate(year, month+1, 0)
+	return last.AddDays(-((int(last.Weekday()) - int(weekday) + 7) % 7))
+}
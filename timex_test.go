@@ -0,0 +1,29 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartOfQuarterDSTGap(t *testing.T) {
+	// 2023-10-01 00:00 在 America/Asuncion 因夏令时切换不存在, 当天从 01:00 开始
+	loc, err := time.LoadLocation("America/Asuncion")
+	if err != nil {
+		t.Skip(err)
+	}
+	tm := time.Date(2023, 11, 15, 12, 0, 0, 0, loc)
+	start := StartOfQuarterByTz(tm, loc)
+	if want := time.Date(2023, 10, 1, 1, 0, 0, 0, loc); !start.Equal(want) {
+		t.Errorf("StartOfQuarterByTz = %v, want %v", start, want)
+	}
+	if !IsStartOfQuarterByTz(start, loc) {
+		t.Errorf("IsStartOfQuarterByTz(%v) = false", start)
+	}
+	if prev := EndOfQuarterByTz(start.Add(-time.Hour), loc); !prev.Equal(start.Add(-time.Nanosecond)) || !IsEndOfQuarterByTz(prev, loc) {
+		t.Errorf("EndOfQuarterByTz of previous quarter = %v, want %v", prev, start.Add(-time.Nanosecond))
+	}
+	end := EndOfQuarterByTz(tm, loc)
+	if want := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Add(-time.Nanosecond); !end.Equal(want) || !IsEndOfQuarterByTz(end, loc) {
+		t.Errorf("EndOfQuarterByTz = %v, want %v", end, want)
+	}
+}
@@ -0,0 +1,127 @@
+package timex
+
+import "time"
+
+// Unit 表示日历时间单位
+type Unit int
+
+const (
+	UnitSecond Unit = iota + 1
+	UnitMinute
+	UnitHour
+	UnitDay
+	UnitWeek // 以周一为一周的开始, 与 ISO 8601 一致
+	UnitMonth
+	UnitQuarter
+	UnitYear
+)
+
+// String 返回时间单位的名称
+func (u Unit) String() string {
+	switch u {
+	case UnitSecond:
+		return "second"
+	case UnitMinute:
+		return "minute"
+	case UnitHour:
+		return "hour"
+	case UnitDay:
+		return "day"
+	case UnitWeek:
+		return "week"
+	case UnitMonth:
+		return "month"
+	case UnitQuarter:
+		return "quarter"
+	case UnitYear:
+		return "year"
+	default:
+		return "unknown"
+	}
+}
+
+// fixedDuration 返回不足一天的单位对应的固定时长, 其他单位返回 0
+func (u Unit) fixedDuration() time.Duration {
+	switch u {
+	case UnitSecond:
+		return time.Second
+	case UnitMinute:
+		return time.Minute
+	case UnitHour:
+		return time.Hour
+	default:
+		return 0
+	}
+}
+
+// TruncateTo 返回 t 在 loc 中所属 unit 周期的开始时间, 如 UnitMonth 返回当月第一天的开始时间, UnitWeek 返回所在周周一的开始时间.
+// 不足一天的单位按照 loc 的墙上时间截断, 因此在偏移不是整小时的时区中也能得到正确的整点;
+// 不小于一天的单位返回对应日期在 loc 中的第一个时间点, 当天零点因夏令时切换而不存在时返回切换的时刻. 无效的 unit 按 UnitDay 处理.
+func TruncateTo(t time.Time, unit Unit, loc *time.Location) time.Time {
+	t = t.In(loc)
+	if d := unit.fixedDuration(); d > 0 {
+		// 墙上时间按秒取余后再加上纳秒, 不能整体转换为 time.Duration, 否则在约 1678 年之前和 2262 年之后会溢出
+		_, offset := t.Zone()
+		secs, unitSecs := t.Unix()+int64(offset), int64(d/time.Second)
+		r := (secs%unitSecs + unitSecs) % unitSecs
+		return t.Add(-(time.Duration(r)*time.Second + time.Duration(t.Nanosecond())))
+	}
+	return startOfLocalDay(truncateDate(DateOf(t), unit), loc)
+}
+
+// RoundTo 返回 t 在 loc 中最接近的 unit 周期边界, 与两侧边界距离相等时取较晚的一个.
+// 距离按照实际时长计算, 因此在夏令时切换的当天, 天和更大单位的中点并不一定是中午 12 点.
+func RoundTo(t time.Time, unit Unit, loc *time.Location) time.Time {
+	floor := TruncateTo(t, unit, loc)
+	if floor.Equal(t) {
+		return floor
+	}
+	ceil := addUnits(floor, unit, 1, loc)
+	if t.Sub(floor) < ceil.Sub(t) {
+		return floor
+	}
+	return ceil
+}
+
+// truncateDate 返回日期所属 unit 周期的第一天, unit 不能小于 UnitDay
+func truncateDate(d Date, unit Unit) Date {
+	switch unit {
+	case UnitWeek:
+		return d.AddDays(-(int(d.Weekday()) + 6) % 7)
+	case UnitMonth:
+		return Date{Year: d.Year, Month: d.Month, Day: 1}
+	case UnitQuarter:
+		return Date{Year: d.Year, Month: d.Month - (d.Month-1)%3, Day: 1}
+	case UnitYear:
+		return Date{Year: d.Year, Month: time.January, Day: 1}
+	default:
+		return d
+	}
+}
+
+// addUnits 返回 t 之后 n 个 unit 的时间, t 应当是 TruncateTo 的结果.
+// 不小于一天的单位按照日历计算, 结果是对应日期在 loc 中的开始时间.
+func addUnits(t time.Time, unit Unit, n int, loc *time.Location) time.Time {
+	if d := unit.fixedDuration(); d > 0 {
+		return t.Add(time.Duration(n) * d)
+	}
+	d := DateOf(t.In(loc))
+	switch unit {
+	case UnitWeek:
+		d = d.AddDays(7 * n)
+	case UnitMonth:
+		d = d.AddMonths(n)
+	case UnitQuarter:
+		d = d.AddMonths(3 * n)
+	case UnitYear:
+		d = d.AddYears(n)
+	default:
+		d = d.AddDays(n)
+	}
+	return startOfLocalDay(d, loc)
+}
+
+// startOfLocalDay 返回日期在 loc 中的第一个时间点, 当天零点因夏令时切换而不存在时返回切换的时刻
+func startOfLocalDay(d Date, loc *time.Location) time.Time {
+	return resolveLocal(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
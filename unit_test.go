@@ -0,0 +1,104 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTruncateTo(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	tm := time.Date(2024, 8, 15, 13, 47, 29, 123456789, kolkata) // 周四
+	cases := []struct {
+		unit Unit
+		want time.Time
+	}{
+		{UnitSecond, time.Date(2024, 8, 15, 13, 47, 29, 0, kolkata)},
+		{UnitMinute, time.Date(2024, 8, 15, 13, 47, 0, 0, kolkata)},
+		{UnitHour, time.Date(2024, 8, 15, 13, 0, 0, 0, kolkata)},
+		{UnitDay, time.Date(2024, 8, 15, 0, 0, 0, 0, kolkata)},
+		{UnitWeek, time.Date(2024, 8, 12, 0, 0, 0, 0, kolkata)},
+		{UnitMonth, time.Date(2024, 8, 1, 0, 0, 0, 0, kolkata)},
+		{UnitQuarter, time.Date(2024, 7, 1, 0, 0, 0, 0, kolkata)},
+		{UnitYear, time.Date(2024, 1, 1, 0, 0, 0, 0, kolkata)},
+		{Unit(0), time.Date(2024, 8, 15, 0, 0, 0, 0, kolkata)},
+	}
+	for _, c := range cases {
+		// 在 UTC 中传入同一时刻, 结果应当按照 loc 的墙上时间截断
+		if got := TruncateTo(tm.UTC(), c.unit, kolkata); !got.Equal(c.want) {
+			t.Errorf("TruncateTo(%v) = %v, want %v", c.unit, got, c.want)
+		}
+	}
+}
+
+func TestTruncateToBeforeEpoch(t *testing.T) {
+	tm := time.Date(1969, 12, 31, 23, 59, 59, 500, time.UTC)
+	if got, want := TruncateTo(tm, UnitHour, time.UTC), time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("TruncateTo hour = %v, want %v", got, want)
+	}
+	if got, want := TruncateTo(tm, UnitSecond, time.UTC), time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("TruncateTo second = %v, want %v", got, want)
+	}
+}
+
+func TestTruncateToFarFromEpoch(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	cases := []struct {
+		t    time.Time
+		unit Unit
+		want time.Time
+	}{
+		{time.Date(2500, 5, 5, 10, 30, 15, 0, time.UTC), UnitHour, time.Date(2500, 5, 5, 10, 0, 0, 0, time.UTC)},
+		{time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC), UnitMinute, time.Date(9999, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{time.Date(2500, 5, 5, 10, 30, 15, 0, kolkata), UnitHour, time.Date(2500, 5, 5, 10, 0, 0, 0, kolkata)},
+		{time.Time{}, UnitHour, time.Time{}},
+		{time.Date(1, 1, 1, 5, 47, 12, 300, time.UTC), UnitHour, time.Date(1, 1, 1, 5, 0, 0, 0, time.UTC)},
+		{time.Date(1, 1, 1, 5, 47, 12, 300, time.UTC), UnitSecond, time.Date(1, 1, 1, 5, 47, 12, 0, time.UTC)},
+		{time.Date(1500, 3, 1, 12, 34, 56, 0, kolkata), UnitMinute, time.Date(1500, 3, 1, 12, 34, 0, 0, kolkata)},
+	}
+	for _, c := range cases {
+		if got := TruncateTo(c.t, c.unit, c.t.Location()); !got.Equal(c.want) {
+			t.Errorf("TruncateTo(%v, %v) = %v, want %v", c.t, c.unit, got, c.want)
+		}
+	}
+	if got, want := RoundTo(time.Date(2500, 5, 5, 10, 30, 15, 0, time.UTC), UnitHour, time.UTC), time.Date(2500, 5, 5, 11, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("RoundTo after 2262 = %v, want %v", got, want)
+	}
+	if got, want := StartOfHour(time.Date(2500, 5, 5, 10, 30, 15, 0, time.UTC)), time.Date(2500, 5, 5, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("StartOfHour after 2262 = %v, want %v", got, want)
+	}
+}
+
+func TestTruncateToDSTGap(t *testing.T) {
+	// 2023-10-01 00:00 在 America/Asuncion 不存在, 当天从 01:00 开始
+	loc, err := time.LoadLocation("America/Asuncion")
+	if err != nil {
+		t.Skip(err)
+	}
+	tm := time.Date(2023, 10, 1, 15, 0, 0, 0, loc)
+	want := time.Date(2023, 10, 1, 1, 0, 0, 0, loc)
+	for _, unit := range []Unit{UnitDay, UnitMonth, UnitQuarter} {
+		if got := TruncateTo(tm, unit, loc); !got.Equal(want) {
+			t.Errorf("TruncateTo(%v) = %v, want %v", unit, got, want)
+		}
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2024, 8, 15, h, m, 0, 0, time.UTC) }
+	cases := []struct {
+		t    time.Time
+		unit Unit
+		want time.Time
+	}{
+		{at(13, 29), UnitHour, at(13, 0)},
+		{at(13, 30), UnitHour, at(14, 0)},
+		{at(13, 0), UnitHour, at(13, 0)},
+		{at(11, 59), UnitDay, at(0, 0)},
+		{at(12, 0), UnitDay, time.Date(2024, 8, 16, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		if got := RoundTo(c.t, c.unit, time.UTC); !got.Equal(c.want) {
+			t.Errorf("RoundTo(%v, %v) = %v, want %v", c.t, c.unit, got, c.want)
+		}
+	}
+}
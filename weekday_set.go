@@ -0,0 +1,69 @@
+package timex
+
+import (
+	"strings"
+	"time"
+)
+
+// WeekdaySet 表示一组星期, 如周末的定义, 零值为空集合
+type WeekdaySet uint8
+
+// DefaultWeekend 是默认的周末定义, 即周六和周日
+const DefaultWeekend WeekdaySet = 1<<time.Saturday | 1<<time.Sunday
+
+// allWeekdays 包含一周的全部七天
+const allWeekdays WeekdaySet = 1<<7 - 1
+
+// NewWeekdaySet 创建包含指定星期的 WeekdaySet, 如中东地区常用的 NewWeekdaySet(time.Friday, time.Saturday)
+func NewWeekdaySet(days ...time.Weekday) WeekdaySet {
+	var s WeekdaySet
+	for _, d := range days {
+		s |= 1 << d
+	}
+	return s & allWeekdays
+}
+
+// Contains 判断集合是否包含星期 d
+func (s WeekdaySet) Contains(d time.Weekday) bool {
+	return d >= time.Sunday && d <= time.Saturday && s&(1<<d) != 0
+}
+
+// Days 返回集合中的星期, 从周日开始排列
+func (s WeekdaySet) Days() []time.Weekday {
+	var days []time.Weekday
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if s.Contains(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// String 返回以逗号分隔的星期名称, 如 "Saturday,Sunday"
+func (s WeekdaySet) String() string {
+	names := make([]string, 0, 7)
+	for _, d := range s.Days() {
+		names = append(names, d.String())
+	}
+	return strings.Join(names, ",")
+}
+
+// IsWeekend 判断时间在其自身时区中是否为周末, weekend 为周末的定义, 通常为 DefaultWeekend
+func IsWeekend(t time.Time, weekend WeekdaySet) bool {
+	return weekend.Contains(t.Weekday())
+}
+
+// IsWeekendByTz 判断时间在指定时区中是否为周末
+func IsWeekendByTz(t time.Time, loc *time.Location, weekend WeekdaySet) bool {
+	return IsWeekend(t.In(loc), weekend)
+}
+
+// IsWeekday 判断时间在其自身时区中是否不是周末, weekend 为周末的定义, 通常为 DefaultWeekend
+func IsWeekday(t time.Time, weekend WeekdaySet) bool {
+	return !IsWeekend(t, weekend)
+}
+
+// IsWeekdayByTz 判断时间在指定时区中是否不是周末
+func IsWeekdayByTz(t time.Time, loc *time.Location, weekend WeekdaySet) bool {
+	return !IsWeekendByTz(t, loc, weekend)
+}
@@ -0,0 +1,37 @@
+package timex
+
+import "time"
+
+// Pair 表示 WindowJoin 中配对成功的两个事件, A 和 B 分别是事件在两个输入中的下标
+type Pair struct {
+	A int
+	B int
+}
+
+// WindowJoin 将两个按时间升序排列的事件流按时间配对, 两个事件的时间相差不超过 tolerance 时可以配对, 每个事件最多参与一次配对.
+// 配对按时间顺序贪心进行, 总是让最早的未配对事件优先配对, 这种方式得到的配对数量最多.
+// 返回按时间排序的配对, 以及 a 和 b 中未能配对的事件下标. 输入没有排序时结果没有意义.
+func WindowJoin(a, b []time.Time, tolerance time.Duration) (pairs []Pair, unmatchedA, unmatchedB []int) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i].Before(b[j].Add(-tolerance)):
+			unmatchedA = append(unmatchedA, i)
+			i++
+		case b[j].Before(a[i].Add(-tolerance)):
+			unmatchedB = append(unmatchedB, j)
+			j++
+		default:
+			pairs = append(pairs, Pair{A: i, B: j})
+			i++
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		unmatchedA = append(unmatchedA, i)
+	}
+	for ; j < len(b); j++ {
+		unmatchedB = append(unmatchedB, j)
+	}
+	return pairs, unmatchedA, unmatchedB
+}
@@ -0,0 +1,66 @@
+package timex
+
+import (
+	"slices"
+	"time"
+)
+
+// WorkingHours 表示某个时区中按星期设置的营业时间, 每天可以有多个时段, 如周一至周五 09:00-12:00 和 13:00-18:00
+type WorkingHours struct {
+	loc      *time.Location
+	days     [7][]TimeOfDayRange
+	holidays HolidayCalendar
+}
+
+// NewWorkingHours 创建WorkingHours, 初始时每天都没有营业时段
+func NewWorkingHours(loc *time.Location) *WorkingHours {
+	return &WorkingHours{loc: loc}
+}
+
+// Set 设置星期 day 的营业时段, 会替换该星期已有的设置.
+// 每个时段必须是同一天内的 [From, To), From 早于 To, 时段之间不能重叠, 否则返回 ErrInvalidWorkingHours.
+func (w *WorkingHours) Set(day time.Weekday, intervals ...TimeOfDayRange) error {
+	if day < time.Sunday || day > time.Saturday {
+		return ErrInvalidWorkingHours
+	}
+	sorted := slices.Clone(intervals)
+	slices.SortFunc(sorted, func(a, b TimeOfDayRange) int { return a.From.Compare(b.From) })
+	for i, r := range sorted {
+		if !r.From.IsValid() || !r.To.IsValid() || !r.From.Before(r.To) || (i > 0 && r.From.Before(sorted[i-1].To)) {
+			return ErrInvalidWorkingHours
+		}
+	}
+	w.days[day] = sorted
+	return nil
+}
+
+// SetHolidayCalendar 设置节假日, 节假日当天没有营业时段, hc 为 nil 时取消设置
+func (w *WorkingHours) SetHolidayCalendar(hc HolidayCalendar) {
+	w.holidays = hc
+}
+
+// Intervals 返回日期 d 当天的营业时段, 按时间排序
+func (w *WorkingHours) Intervals(d Date) []*TimeRange {
+	if w.holidays != nil && w.holidays.IsHoliday(d) {
+		return nil
+	}
+	var ranges []*TimeRange
+	for _, r := range w.days[d.Weekday()] {
+		ranges = append(ranges, &TimeRange{start: r.From.On(d, w.loc), end: r.To.On(d, w.loc), startInclusive: true})
+	}
+	return ranges
+}
+
+// WorkingDuration 返回时间范围内包含的营业时长, 如工单在营业时间内等待了多久
+func (w *WorkingHours) WorkingDuration(tr *TimeRange) time.Duration {
+	var total time.Duration
+	end := DateOfByTz(tr.end, w.loc)
+	for d := DateOfByTz(tr.start, w.loc); !d.After(end); d = d.AddDays(1) {
+		for _, r := range w.Intervals(d) {
+			if s, e, ok := overlapBounds(r, tr); ok {
+				total += e.Sub(s)
+			}
+		}
+	}
+	return total
+}
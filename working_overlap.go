@@ -0,0 +1,15 @@
+package timex
+
+import "time"
+
+// WorkingOverlap 返回两个时区在 date 这一天的共同工作时间, 两地的工作时间均为各自当地的 [workStart, workEnd).
+// date 分别按照两地的当地日期理解, 因此结果考虑了当天两地各自的夏令时状态. 没有共同工作时间时返回 nil 和 0.
+func WorkingOverlap(locA, locB *time.Location, workStart, workEnd TimeOfDay, date Date) (*TimeRange, time.Duration) {
+	a := &TimeRange{start: workStart.On(date, locA), end: workEnd.On(date, locA), startInclusive: true}
+	b := &TimeRange{start: workStart.On(date, locB), end: workEnd.On(date, locB), startInclusive: true}
+	s, e, ok := overlapBounds(a, b)
+	if !ok {
+		return nil, 0
+	}
+	return &TimeRange{start: s, end: e, startInclusive: true}, e.Sub(s)
+}
@@ -0,0 +1,124 @@
+package timex
+
+import (
+	"cmp"
+	"time"
+)
+
+const yearMonthLayout = "2006-01"
+
+// YearMonth 表示一个日历月份, 如 2024-03, 可以作为 map 的键使用.
+// 文本及 JSON 编码格式为 "YYYY-MM".
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// NewYearMonth 创建YearMonth, 超出范围的月份会被规范化, 如 2024-13 会变为 2025-01
+func NewYearMonth(year int, month time.Month) YearMonth {
+	year, month = addMonths(year, time.January, int(month)-1)
+	return YearMonth{Year: year, Month: month}
+}
+
+// YearMonthOf 返回时间在其自身时区中所属的月份
+func YearMonthOf(t time.Time) YearMonth {
+	return YearMonth{Year: t.Year(), Month: t.Month()}
+}
+
+// YearMonthOfByTz 返回时间在指定时区中所属的月份
+func YearMonthOfByTz(t time.Time, loc *time.Location) YearMonth {
+	return YearMonthOf(t.In(loc))
+}
+
+// ParseYearMonth 解析 "YYYY-MM" 格式的月份
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse(yearMonthLayout, s)
+	if err != nil {
+		return YearMonth{}, err
+	}
+	return YearMonthOf(t), nil
+}
+
+// Next 返回下一个月
+func (ym YearMonth) Next() YearMonth {
+	return ym.AddMonths(1)
+}
+
+// Prev 返回上一个月
+func (ym YearMonth) Prev() YearMonth {
+	return ym.AddMonths(-1)
+}
+
+// AddMonths 返回 n 个月之后的月份, n 可以为负数
+func (ym YearMonth) AddMonths(n int) YearMonth {
+	year, month := addMonths(ym.Year, ym.Month, n)
+	return YearMonth{Year: year, Month: month}
+}
+
+// MonthsSince 返回从 other 到 ym 经过的月数, ym 早于 other 时为负数
+func (ym YearMonth) MonthsSince(other YearMonth) int {
+	return (ym.Year-other.Year)*12 + int(ym.Month) - int(other.Month)
+}
+
+// Compare 比较两个月份, ym 早于 other 时返回 -1, 晚于时返回 1, 相同时返回 0
+func (ym YearMonth) Compare(other YearMonth) int {
+	return cmp.Compare(ym.MonthsSince(other), 0)
+}
+
+// Before 判断 ym 是否早于 other
+func (ym YearMonth) Before(other YearMonth) bool {
+	return ym.Compare(other) < 0
+}
+
+// After 判断 ym 是否晚于 other
+func (ym YearMonth) After(other YearMonth) bool {
+	return ym.Compare(other) > 0
+}
+
+// Days 返回该月的天数
+func (ym YearMonth) Days() int {
+	return DaysInMonth(ym.Year, ym.Month)
+}
+
+// FirstDay 返回该月的第一天
+func (ym YearMonth) FirstDay() Date {
+	return Date{Year: ym.Year, Month: ym.Month, Day: 1}
+}
+
+// LastDay 返回该月的最后一天
+func (ym YearMonth) LastDay() Date {
+	return Date{Year: ym.Year, Month: ym.Month, Day: ym.Days()}
+}
+
+// ToDateRange 转换为包含该月每一天的 DateRange
+func (ym YearMonth) ToDateRange() *DateRange {
+	return &DateRange{start: ym.FirstDay(), end: ym.LastDay()}
+}
+
+// ToTimeRange 转换为指定时区中从该月第一天零点 (包含) 到下月第一天零点 (不包含) 的 TimeRange
+func (ym YearMonth) ToTimeRange(loc *time.Location) *TimeRange {
+	return ym.ToDateRange().ToTimeRange(loc)
+}
+
+// String 返回 "YYYY-MM" 格式的文本
+func (ym YearMonth) String() string {
+	return ym.FirstDay().In(time.UTC).Format(yearMonthLayout)
+}
+
+// MarshalText 实现 encoding.TextMarshaler
+func (ym YearMonth) MarshalText() ([]byte, error) {
+	if ym.Month < time.January || ym.Month > time.December {
+		return nil, ErrInvalidDate
+	}
+	return []byte(ym.String()), nil
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler
+func (ym *YearMonth) UnmarshalText(text []byte) error {
+	v, err := ParseYearMonth(string(text))
+	if err != nil {
+		return err
+	}
+	*ym = v
+	return nil
+}
@@ -0,0 +1,134 @@
+package timex
+
+import "time"
+
+// ZoneDifference 表示两个时区数据在某段时间内给出了不同的偏移
+type ZoneDifference struct {
+	Range   *TimeRange // 偏移不一致的时间范围
+	NameA   string     // a 在该范围内的时区缩写
+	OffsetA int        // a 在该范围内相对 UTC 的偏移秒数
+	NameB   string     // b 在该范围内的时区缩写
+	OffsetB int        // b 在该范围内相对 UTC 的偏移秒数
+}
+
+// ZoneDiff 比较同一时区的两个版本 (例如分别通过 time.LoadLocationFromTZData 加载的新旧 tzdata) 在给定时间范围内的差异,
+// 返回所有偏移不一致的时间段, 相邻且偏移相同的时间段会被合并, 仅时区缩写不同而偏移相同的时间段不视为差异.
+// tzdata 更新后, 可以用它找出哪些已存储的未来时间点的含义发生了变化.
+func ZoneDiff(a, b *time.Location, tr *TimeRange) []ZoneDifference {
+	start, end := tr.start, tr.end
+	if !tr.startInclusive {
+		start = start.Add(time.Nanosecond)
+	}
+	if tr.endInclusive {
+		end = end.Add(time.Nanosecond)
+	}
+	if !start.Before(end) {
+		return nil
+	}
+
+	segsA, segsB := zoneSegments(a, start, end), zoneSegments(b, start, end)
+	var diffs []ZoneDifference
+	var last *zoneSegment
+	for i, j := 0, 0; i < len(segsA) && j < len(segsB); {
+		sa, sb := segsA[i], segsB[j]
+		s, e := sa.start, sa.end
+		if sb.start.After(s) {
+			s = sb.start
+		}
+		if sb.end.Before(e) {
+			e = sb.end
+		}
+		if sa.offset != sb.offset {
+			n := len(diffs)
+			if last != nil && last.end.Equal(s) && diffs[n-1].OffsetA == sa.offset && diffs[n-1].OffsetB == sb.offset {
+				last.end = e
+			} else {
+				diffs = append(diffs, ZoneDifference{NameA: sa.name, OffsetA: sa.offset, NameB: sb.name, OffsetB: sb.offset})
+				last = &zoneSegment{start: s, end: e}
+			}
+			diffs[len(diffs)-1].Range = segmentToTimeRange(last.start, last.end, start, end, tr)
+		}
+
+		if !sa.end.After(e) {
+			i++
+		}
+		if !sb.end.After(e) {
+			j++
+		}
+	}
+
+	return diffs
+}
+
+// DSTTransitions 返回 loc 中发生在时间范围内的所有偏移变化时刻 (通常是夏令时切换), 按时间排序, 结果位于 loc 中.
+// 返回的时刻是新偏移开始生效的时间点, 只有时区缩写变化而偏移不变的时刻不计入.
+func (tr *TimeRange) DSTTransitions(loc *time.Location) []time.Time {
+	var transitions []time.Time
+	segs := zoneSegments(loc, tr.start.Add(-time.Nanosecond), tr.end.Add(time.Nanosecond))
+	for i := 1; i < len(segs); i++ {
+		if segs[i].offset != segs[i-1].offset && tr.Contains(segs[i].start) {
+			transitions = append(transitions, segs[i].start.In(loc))
+		}
+	}
+	return transitions
+}
+
+// zoneSegment 表示一段偏移和缩写都不变的半开区间 [start, end)
+type zoneSegment struct {
+	start  time.Time
+	end    time.Time
+	name   string
+	offset int
+}
+
+// zoneSegments 将 [start, end) 按照 loc 的时区规则切分为若干偏移不变的时间段
+func zoneSegments(loc *time.Location, start, end time.Time) []zoneSegment {
+	var segs []zoneSegment
+	for t := start; t.Before(end); {
+		lt := t.In(loc)
+		name, offset := lt.Zone()
+		_, e := lt.ZoneBounds()
+		if e.IsZero() || e.After(end) {
+			e = end
+		}
+		segs = append(segs, zoneSegment{start: t, end: e, name: name, offset: offset})
+		t = e
+	}
+	return segs
+}
+
+// segmentToTimeRange 将 zoneSegments 计算得到的半开区间还原到原始时间范围的边界语义上.
+// start, end 是计算时使用的半开区间边界, 与它们重合的段边界按照 tr 的开闭性表示.
+func segmentToTimeRange(s, e, start, end time.Time, tr *TimeRange) *TimeRange {
+	r := &TimeRange{start: s, end: e, startInclusive: true}
+	if s.Equal(start) {
+		r.start, r.startInclusive = tr.start, tr.startInclusive
+	}
+	if e.Equal(end) {
+		r.end, r.endInclusive = tr.end, tr.endInclusive
+	}
+	return r
+}
+
+// resolveLocal 将 loc 中的墙上时间解析为时间点.
+// 墙上时间在夏令时切换时出现两次时, 返回较早的那个时间点;
+// 墙上时间因切换被跳过而不存在时, 按照切换前的偏移解析, 即向后顺延跳过的时长 (如跳过 02:00-03:00 时, 02:30 解析为 03:30).
+func resolveLocal(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) time.Time {
+	naive := time.Date(year, month, day, hour, min, sec, nsec, time.UTC)
+	segs := zoneSegments(loc, naive.Add(-30*time.Hour), naive.Add(30*time.Hour))
+	for _, seg := range segs {
+		u := naive.Add(-time.Duration(seg.offset) * time.Second)
+		if !u.Before(seg.start) && u.Before(seg.end) {
+			return u.In(loc)
+		}
+	}
+	for i := 0; i+1 < len(segs); i++ {
+		before, after := segs[i], segs[i+1]
+		tb := before.end.Add(time.Duration(before.offset) * time.Second)
+		ta := before.end.Add(time.Duration(after.offset) * time.Second)
+		if !naive.Before(tb) && naive.Before(ta) {
+			return naive.Add(-time.Duration(before.offset) * time.Second).In(loc)
+		}
+	}
+	return time.Date(year, month, day, hour, min, sec, nsec, loc)
+}